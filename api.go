@@ -35,6 +35,10 @@ type API struct {
 	User  string
 	Debug bool
 
+	// LintContent lints merge tags against the campaign's list before
+	// UpdateCampaignContent sends new content, see MergeTagLintError.
+	LintContent bool
+
 	endpoint string
 }
 
@@ -54,10 +58,7 @@ func New(apiKey string) *API {
 
 // Request will make a call to the actual API.
 func (api *API) Request(ctx context.Context, method, path string, params QueryParams, body, response any) error {
-	client := &http.Client{Transport: api.Transport}
-	if api.Timeout > 0 {
-		client.Timeout = api.Timeout
-	}
+	client := api.httpClient()
 
 	requestURL := fmt.Sprintf("%s%s", api.endpoint, path)
 	if api.Debug {
@@ -140,6 +141,15 @@ func (api *API) Request(ctx context.Context, method, path string, params QueryPa
 	return parseAPIError(data)
 }
 
+func (api *API) httpClient() *http.Client {
+	client := &http.Client{Transport: api.Transport}
+	if api.Timeout > 0 {
+		client.Timeout = api.Timeout
+	}
+
+	return client
+}
+
 // RequestOk Make Request ignoring body and return true if HTTP status code is 2xx.
 func (api *API) RequestOk(ctx context.Context, method, path string) (bool, error) {
 	err := api.Request(ctx, method, path, nil, nil, nil)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"strings"
@@ -22,11 +23,25 @@ func fatalIf(t *testing.T, err error) {
 	}
 }
 
+// resources routed to delegate besides /somewhere, anything else is a 404
+var testResources = []string{
+	"/lists/", "/campaigns", "/campaigns/", "/campaign-folders", "/template-folders", "/templates/", "/reports/", "/automations", "/ecommerce/",
+}
+
 func TestMain(m *testing.M) {
-	http.HandleFunc("/somewhere", func(w http.ResponseWriter, r *http.Request) {
-		delegate(w, r)
-	})
-	go func() { _ = http.ListenAndServe(":9999", nil) }()
+	for _, pattern := range append([]string{"/somewhere"}, testResources...) {
+		http.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			delegate(w, r)
+		})
+	}
+
+	// listen before running the tests so the first request can't race the
+	// server start
+	listener, err := net.Listen("tcp", ":9999")
+	if err != nil {
+		panic(err)
+	}
+	go func() { _ = http.Serve(listener, nil) }()
 	os.Exit(m.Run())
 }
 
@@ -0,0 +1,189 @@
+package gochimp3
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+)
+
+// CampaignID returns the ID Mailchimp reports the email under. Every
+// automation email is a campaign of type automation with the email's ID.
+func (email *AutomationEmail) CampaignID() string {
+	return email.ID
+}
+
+func (email *AutomationEmail) GetReport(ctx context.Context, params *BasicQueryParams) (*CampaignReport, error) {
+	if err := email.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return email.api.GetReport(ctx, email.CampaignID(), params)
+}
+
+func (email *AutomationEmail) GetOpenDetails(ctx context.Context, params *ReportActivityQueryParams) (*ListOfOpenDetails, error) {
+	if err := email.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return email.api.GetOpenDetails(ctx, email.CampaignID(), params)
+}
+
+func (email *AutomationEmail) GetClickDetails(ctx context.Context, params *ExtendedQueryParams) (*ListOfClickDetails, error) {
+	if err := email.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return email.api.GetClickDetails(ctx, email.CampaignID(), params)
+}
+
+func (email *AutomationEmail) GetUnsubscribes(ctx context.Context, params *ExtendedQueryParams) (*ListOfUnsubscribes, error) {
+	if err := email.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return email.api.GetUnsubscribes(ctx, email.CampaignID(), params)
+}
+
+func (email *AutomationEmail) GetEmailActivity(ctx context.Context, params *ReportActivityQueryParams) (*ListOfEmailActivity, error) {
+	if err := email.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return email.api.GetEmailActivity(ctx, email.CampaignID(), params)
+}
+
+func (email *AutomationEmail) IterateEmailActivity(params *ReportActivityQueryParams) *EmailActivityIterator {
+	if err := email.CanMakeRequest(); err != nil {
+		return &EmailActivityIterator{err: err}
+	}
+
+	return email.api.IterateEmailActivity(email.CampaignID(), params)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Funnel
+// ------------------------------------------------------------------------------------------------
+
+// AutomationFunnelStep is one email of a workflow. Rates are fractions of the
+// emails delivered by the step, Retention is the share of the first step's
+// sends that reached this step.
+type AutomationFunnelStep struct {
+	EmailID      string
+	Position     int
+	SubjectLine  string
+	Status       string
+	Sent         int
+	Delivered    int
+	UniqueOpens  int
+	UniqueClicks int
+	Unsubscribed int
+
+	OpenRate        float64
+	ClickRate       float64
+	UnsubscribeRate float64
+	Retention       float64
+
+	// DropOff is the share of the previous step's sends that did not get
+	// this email, zero for the first step
+	DropOff float64
+}
+
+type AutomationFunnel struct {
+	WorkflowID string
+	Title      string
+	Steps      []AutomationFunnelStep
+}
+
+// BuildAutomationFunnel orders the emails by position and fills each step from
+// its report. Emails without a report, e.g. ones that never sent, fall back to
+// the email's own summary.
+func BuildAutomationFunnel(emails []AutomationEmail, reports map[string]*CampaignReport) *AutomationFunnel {
+	funnel := new(AutomationFunnel)
+
+	sorted := make([]AutomationEmail, len(emails))
+	copy(sorted, emails)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+
+	for i, email := range sorted {
+		if funnel.WorkflowID == "" {
+			funnel.WorkflowID = email.WorkflowID
+		}
+
+		step := AutomationFunnelStep{
+			EmailID:     email.ID,
+			Position:    email.Position,
+			SubjectLine: email.Settings.SubjectLine,
+			Status:      email.Status,
+		}
+
+		if report := reports[email.ID]; report != nil {
+			step.Sent = report.EmailsSent
+			step.Delivered = report.EmailsSent - report.Bounces.Total()
+			step.UniqueOpens = report.Opens.UniqueOpens
+			step.UniqueClicks = report.Clicks.UniqueSubscriberClicks
+			step.Unsubscribed = report.Unsubscribed
+		} else {
+			step.Sent = email.EmailsSent
+			step.Delivered = email.EmailsSent
+			step.UniqueOpens = email.ReportSummary.UniqueOpens
+			step.UniqueClicks = email.ReportSummary.SubscriberClicks
+		}
+
+		step.OpenRate = ratio(float64(step.UniqueOpens), step.Delivered)
+		step.ClickRate = ratio(float64(step.UniqueClicks), step.Delivered)
+		step.UnsubscribeRate = ratio(float64(step.Unsubscribed), step.Delivered)
+		if i == 0 {
+			step.Retention = ratio(float64(step.Sent), step.Sent)
+		} else {
+			first, previous := funnel.Steps[0], funnel.Steps[i-1]
+			step.Retention = ratio(float64(step.Sent), first.Sent)
+			if previous.Sent > 0 {
+				step.DropOff = 1 - ratio(float64(step.Sent), previous.Sent)
+			}
+		}
+
+		funnel.Steps = append(funnel.Steps, step)
+	}
+
+	return funnel
+}
+
+// GetAutomationFunnel fetches the emails of a workflow and their reports.
+func (api *API) GetAutomationFunnel(ctx context.Context, workflowID string) (*AutomationFunnel, error) {
+	emails, err := api.GetAutomationEmails(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make(map[string]*CampaignReport, len(emails.Emails))
+	for _, email := range emails.Emails {
+		report, err := api.GetReport(ctx, email.CampaignID(), nil)
+		if isNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "report of automation email %s", email.ID)
+		}
+		reports[email.ID] = report
+	}
+
+	funnel := BuildAutomationFunnel(emails.Emails, reports)
+	funnel.WorkflowID = workflowID
+	return funnel, nil
+}
+
+func (auto *Automation) GetFunnel(ctx context.Context) (*AutomationFunnel, error) {
+	if err := auto.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	funnel, err := auto.api.GetAutomationFunnel(ctx, auto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	funnel.Title = auto.Settings.Title
+	return funnel, nil
+}
@@ -0,0 +1,48 @@
+package gochimp3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAutomationFunnel(t *testing.T) {
+	emails := []AutomationEmail{
+		{ID: "e3", WorkflowID: "w", Position: 3, EmailsSent: 40, ReportSummary: ReportSummary{UniqueOpens: 10}},
+		{ID: "e1", WorkflowID: "w", Position: 1},
+		{ID: "e2", WorkflowID: "w", Position: 2, Settings: AutomationSettingsLong{Title: "Second email"}},
+	}
+	reports := map[string]*CampaignReport{
+		"e1": {EmailsSent: 100, Bounces: ReportBounces{HardBounces: 4}, Opens: ReportOpens{UniqueOpens: 48}, Clicks: ReportClicks{UniqueSubscriberClicks: 12}},
+		"e2": {EmailsSent: 80, Opens: ReportOpens{UniqueOpens: 20}, Unsubscribed: 4},
+	}
+
+	funnel := BuildAutomationFunnel(emails, reports)
+	assert.Equal(t, "w", funnel.WorkflowID)
+	assert.Empty(t, funnel.Title)
+	assert.Len(t, funnel.Steps, 3)
+
+	first, second, third := funnel.Steps[0], funnel.Steps[1], funnel.Steps[2]
+	assert.Equal(t, "e1", first.EmailID)
+	assert.Equal(t, 96, first.Delivered)
+	assert.InDelta(t, 0.5, first.OpenRate, 1e-9)
+	assert.InDelta(t, 0.125, first.ClickRate, 1e-9)
+	assert.InDelta(t, 1, first.Retention, 1e-9)
+	assert.Zero(t, first.DropOff)
+
+	assert.InDelta(t, 0.05, second.UnsubscribeRate, 1e-9)
+	assert.InDelta(t, 0.8, second.Retention, 1e-9)
+	assert.InDelta(t, 0.2, second.DropOff, 1e-9)
+
+	assert.Equal(t, "e3", third.EmailID)
+	assert.InDelta(t, 0.25, third.OpenRate, 1e-9)
+	assert.InDelta(t, 0.4, third.Retention, 1e-9)
+	assert.InDelta(t, 0.5, third.DropOff, 1e-9)
+}
+
+func TestAutomationEmailIterateRequiresID(t *testing.T) {
+	it := new(AutomationEmail).IterateEmailActivity(nil)
+	assert.False(t, it.Next(context.Background()))
+	assert.Error(t, it.Err())
+}
@@ -129,6 +129,10 @@ func (auto *Automation) CanMakeRequest() error {
 }
 
 func (api *API) GetAutomations(ctx context.Context, params *BasicQueryParams) (*ListOfAutomations, error) {
+	return api.getAutomations(ctx, params)
+}
+
+func (api *API) getAutomations(ctx context.Context, params QueryParams) (*ListOfAutomations, error) {
 	response := new(ListOfAutomations)
 
 	err := api.Request(ctx, http.MethodGet, automationsPath, params, nil, response)
@@ -136,13 +140,32 @@ func (api *API) GetAutomations(ctx context.Context, params *BasicQueryParams) (*
 		return nil, err
 	}
 
-	for _, l := range response.Automations {
-		l.api = api
+	for i := range response.Automations {
+		response.Automations[i].api = api
 	}
 
 	return response, nil
 }
 
+// allAutomations pages through every automation of the account.
+func (api *API) allAutomations(ctx context.Context) ([]Automation, error) {
+	params := &ExtendedQueryParams{Count: maxPageSize}
+
+	var automations []Automation
+	for {
+		page, err := api.getAutomations(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		automations = append(automations, page.Automations...)
+		params.Offset += len(page.Automations)
+		if len(page.Automations) == 0 || params.Offset >= page.TotalItems {
+			return automations, nil
+		}
+	}
+}
+
 // TODO query params?
 func (api *API) GetAutomation(ctx context.Context, id string) (*Automation, error) {
 	endpoint := fmt.Sprintf(singleAutomationPath, id)
@@ -264,11 +287,16 @@ func (api *API) GetAutomationEmails(ctx context.Context, automationID string) (*
 	endpoint := fmt.Sprintf(automationEmailPath, automationID)
 	response := new(ListOfEmails)
 
-	for _, l := range response.Emails {
-		l.api = api
+	err := api.Request(ctx, http.MethodGet, endpoint, nil, nil, response)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, api.Request(ctx, http.MethodGet, endpoint, nil, nil, response)
+	for i := range response.Emails {
+		response.Emails[i].api = api
+	}
+
+	return response, nil
 }
 
 func (auto *Automation) GetEmail(ctx context.Context, id string) (*AutomationEmail, error) {
@@ -326,11 +354,17 @@ func (api *API) GetAutomationQueues(ctx context.Context, workflowID, emailID str
 	endpoint := fmt.Sprintf(automationQueuesPath, workflowID, emailID)
 
 	response := new(ListOfAutomationQueues)
-	for _, l := range response.Queues {
-		l.api = api
+
+	err := api.Request(ctx, http.MethodGet, endpoint, nil, nil, response)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, api.Request(ctx, http.MethodGet, endpoint, nil, nil, response)
+	for i := range response.Queues {
+		response.Queues[i].api = api
+	}
+
+	return response, nil
 }
 
 func (email *AutomationEmail) GetQueue(ctx context.Context, id string) (*AutomationQueue, error) {
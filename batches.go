@@ -1,15 +1,29 @@
 package gochimp3
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	json "github.com/json-iterator/go"
 )
 
 const (
 	batchesPath     = "/batches"
 	singleBatchPath = batchesPath + "/%s"
+
+	BatchStatusPending       = "pending"
+	BatchStatusPreprocessing = "preprocessing"
+	BatchStatusStarted       = "started"
+	BatchStatusFinalizing    = "finalizing"
+	BatchStatusFinished      = "finished"
 )
 
 func (api *API) GetBatchOperations(ctx context.Context, params *ListQueryParams) (*ListOfBatchOperations, error) {
@@ -74,3 +88,100 @@ type BatchOperation struct {
 
 	api *API
 }
+
+// BatchOperationResult is the outcome of a single operation of a finished
+// batch. Response holds the raw JSON body the operation returned.
+type BatchOperationResult struct {
+	StatusCode  int    `json:"status_code"`
+	OperationID string `json:"operation_id"`
+	Response    string `json:"response"`
+}
+
+// Succeeded reports whether the operation returned a 2xx status code.
+func (result *BatchOperationResult) Succeeded() bool {
+	return result.StatusCode >= 200 && result.StatusCode < 300
+}
+
+// Error returns the API error of a failed operation, nil if it succeeded.
+func (result *BatchOperationResult) Error() error {
+	if result.Succeeded() {
+		return nil
+	}
+
+	apiError := new(APIError)
+	if err := json.Unmarshal([]byte(result.Response), apiError); err == nil && apiError.HasError() {
+		return apiError
+	}
+
+	return errors.Errorf("operation %s failed with status %d", result.OperationID, result.StatusCode)
+}
+
+// WaitForBatchOperation polls the batch every interval until it is finished.
+func (api *API) WaitForBatchOperation(ctx context.Context, id string, interval time.Duration) (*BatchOperationResponse, error) {
+	for {
+		batch, err := api.GetBatchOperation(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if batch.Status == BatchStatusFinished {
+			return batch, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return batch, errors.WithStack(ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// GetResults downloads and unpacks the results archive of a finished batch.
+func (batch *BatchOperationResponse) GetResults(ctx context.Context) ([]BatchOperationResult, error) {
+	if batch.ResponseBodyUrl == "" {
+		return nil, errors.Errorf("batch %s has no results yet, status is %q", batch.ID, batch.Status)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, batch.ResponseBodyUrl, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	resp, err := batch.api.httpClient().Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.Errorf("downloading batch %s results failed with status %d", batch.ID, resp.StatusCode)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	var results []BatchOperationResult
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".json") {
+			continue
+		}
+
+		var part []BatchOperationResult
+		if err := json.NewDecoder(archive).Decode(&part); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		results = append(results, part...)
+	}
+}
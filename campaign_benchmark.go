@@ -0,0 +1,220 @@
+package gochimp3
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+)
+
+var campaignBenchmarkColumns = []string{
+	"campaign_id", "title", "subject_line", "send_time", "emails_sent", "delivered",
+	"open_rate", "open_rate_delta", "click_rate", "click_rate_delta",
+	"bounce_rate", "bounce_rate_delta", "unsubscribe_rate", "unsubscribe_rate_delta",
+	"revenue", "revenue_per_recipient", "currency_code",
+}
+
+// CampaignBenchmarkRow holds the rates of one campaign, or of all campaigns
+// for the total row. Rates are fractions like the ones in reports, deltas are
+// the rate minus the industry rate.
+type CampaignBenchmarkRow struct {
+	CampaignID  string `json:"campaign_id"`
+	Title       string `json:"title"`
+	SubjectLine string `json:"subject_line"`
+	SendTime    string `json:"send_time"`
+	EmailsSent  int    `json:"emails_sent"`
+	Delivered   int    `json:"delivered"`
+
+	OpenRate             float64 `json:"open_rate"`
+	OpenRateDelta        float64 `json:"open_rate_delta"`
+	ClickRate            float64 `json:"click_rate"`
+	ClickRateDelta       float64 `json:"click_rate_delta"`
+	BounceRate           float64 `json:"bounce_rate"`
+	BounceRateDelta      float64 `json:"bounce_rate_delta"`
+	UnsubscribeRate      float64 `json:"unsubscribe_rate"`
+	UnsubscribeRateDelta float64 `json:"unsubscribe_rate_delta"`
+
+	Revenue             Money  `json:"revenue"`
+	RevenuePerRecipient Money  `json:"revenue_per_recipient"`
+	CurrencyCode        string `json:"currency_code"`
+
+	uniqueOpens   int
+	uniqueClicks  int
+	bounces       int
+	unsubscribes  int
+	industryUnsub float64
+}
+
+func (row *CampaignBenchmarkRow) record() []string {
+	rate := func(f float64) string { return strconv.FormatFloat(f, 'f', 4, 64) }
+	return []string{
+		row.CampaignID, row.Title, row.SubjectLine, row.SendTime,
+		strconv.Itoa(row.EmailsSent), strconv.Itoa(row.Delivered),
+		rate(row.OpenRate), rate(row.OpenRateDelta), rate(row.ClickRate), rate(row.ClickRateDelta),
+		rate(row.BounceRate), rate(row.BounceRateDelta), rate(row.UnsubscribeRate), rate(row.UnsubscribeRateDelta),
+		row.Revenue.String(), row.RevenuePerRecipient.String(), row.CurrencyCode,
+	}
+}
+
+// CampaignBenchmark compares campaigns against the account's industry stats.
+// The account stats have no unsubscribe rate, so the unsubscribe delta uses
+// the industry rate from each campaign's report instead.
+type CampaignBenchmark struct {
+	Industry IndustryStats          `json:"industry"`
+	Rows     []CampaignBenchmarkRow `json:"rows"`
+	Total    CampaignBenchmarkRow   `json:"total"`
+}
+
+// Columns returns the column names of the rows in the order of Write.
+func (benchmark *CampaignBenchmark) Columns() []string {
+	return campaignBenchmarkColumns
+}
+
+// Write writes the rows followed by the total as CSV or NDJSON, format being
+// one of the ExportFormat* consts.
+func (benchmark *CampaignBenchmark) Write(w io.Writer, format string) error {
+	writer, err := newExportWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	if err := writer.Header(campaignBenchmarkColumns); err != nil {
+		return err
+	}
+
+	for i := range benchmark.Rows {
+		if err := writer.Write(benchmark.Rows[i].record(), &benchmark.Rows[i]); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write(benchmark.Total.record(), &benchmark.Total); err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// BenchmarkCampaigns benchmarks the sent campaigns matched by params against
+// the industry stats returned by GetRoot.
+func (api *API) BenchmarkCampaigns(ctx context.Context, params *CampaignQueryParams) (*CampaignBenchmark, error) {
+	root, err := api.GetRoot(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := CampaignQueryParams{}
+	if params != nil {
+		query = *params
+	}
+	if query.Status == "" {
+		query.Status = CampaignStatusSent
+	}
+	if query.Count <= 0 || query.Count > maxPageSize {
+		query.Count = maxPageSize
+	}
+
+	var reports []*CampaignReport
+	for {
+		page, err := api.GetCampaigns(ctx, &query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, campaign := range page.Campaigns {
+			if campaign.Status != CampaignStatusSent {
+				continue
+			}
+
+			report, err := api.GetReport(ctx, campaign.ID, nil)
+			if err != nil {
+				return nil, errors.Wrapf(err, "report of campaign %s", campaign.ID)
+			}
+			reports = append(reports, report)
+		}
+
+		query.Offset += len(page.Campaigns)
+		if len(page.Campaigns) == 0 || query.Offset >= page.TotalItems {
+			break
+		}
+	}
+
+	return BenchmarkReports(root.IndustryStats, reports...), nil
+}
+
+// BenchmarkReports builds the benchmark table from already fetched reports.
+func BenchmarkReports(industry IndustryStats, reports ...*CampaignReport) *CampaignBenchmark {
+	benchmark := &CampaignBenchmark{Industry: industry}
+	benchmark.Total.CampaignID = "total"
+
+	currencies := make(map[string]bool)
+	for _, report := range reports {
+		row := CampaignBenchmarkRow{
+			CampaignID:    report.ID,
+			Title:         report.CampaignTitle,
+			SubjectLine:   report.SubjectLine,
+			SendTime:      report.SendTime,
+			EmailsSent:    report.EmailsSent,
+			Revenue:       report.Ecommerce.TotalRevenue,
+			CurrencyCode:  report.Ecommerce.CurrencyCode,
+			uniqueOpens:   report.Opens.UniqueOpens,
+			uniqueClicks:  report.Clicks.UniqueSubscriberClicks,
+			bounces:       report.Bounces.Total(),
+			unsubscribes:  report.Unsubscribed,
+			industryUnsub: report.IndustryStats.UnsubRate,
+		}
+		row.Delivered = row.EmailsSent - row.bounces
+		row.calculate(industry)
+		benchmark.Rows = append(benchmark.Rows, row)
+
+		total := &benchmark.Total
+		total.EmailsSent += row.EmailsSent
+		total.Delivered += row.Delivered
+		total.Revenue = total.Revenue.Add(row.Revenue)
+		total.uniqueOpens += row.uniqueOpens
+		total.uniqueClicks += row.uniqueClicks
+		total.bounces += row.bounces
+		total.unsubscribes += row.unsubscribes
+		// weight the industry unsubscribe rate by the delivered emails
+		total.industryUnsub += row.industryUnsub * float64(row.Delivered)
+		if row.CurrencyCode != "" {
+			currencies[row.CurrencyCode] = true
+		}
+	}
+
+	total := &benchmark.Total
+	total.industryUnsub = ratio(total.industryUnsub, total.Delivered)
+	total.calculate(industry)
+
+	// revenue in different currencies can't be summed up
+	if len(currencies) > 1 {
+		total.Revenue, total.RevenuePerRecipient = 0, 0
+	} else {
+		for currency := range currencies {
+			total.CurrencyCode = currency
+		}
+	}
+
+	return benchmark
+}
+
+func (row *CampaignBenchmarkRow) calculate(industry IndustryStats) {
+	row.OpenRate = ratio(float64(row.uniqueOpens), row.Delivered)
+	row.ClickRate = ratio(float64(row.uniqueClicks), row.Delivered)
+	row.BounceRate = ratio(float64(row.bounces), row.EmailsSent)
+	row.UnsubscribeRate = ratio(float64(row.unsubscribes), row.Delivered)
+	row.RevenuePerRecipient = row.Revenue.Div(row.EmailsSent)
+
+	row.OpenRateDelta = row.OpenRate - industry.OpenRate
+	row.ClickRateDelta = row.ClickRate - industry.ClickRate
+	row.BounceRateDelta = row.BounceRate - industry.BounceRate
+	row.UnsubscribeRateDelta = row.UnsubscribeRate - row.industryUnsub
+}
+
+func ratio(part float64, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+
+	return part / float64(total)
+}
@@ -0,0 +1,53 @@
+package gochimp3
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBenchmarkReports(t *testing.T) {
+	industry := IndustryStats{OpenRate: 0.2, ClickRate: 0.02, BounceRate: 0.01}
+	a := &CampaignReport{
+		ID:            "a",
+		EmailsSent:    100,
+		Unsubscribed:  1,
+		Bounces:       ReportBounces{HardBounces: 2, SoftBounces: 2},
+		Opens:         ReportOpens{UniqueOpens: 48},
+		Clicks:        ReportClicks{UniqueSubscriberClicks: 6},
+		IndustryStats: ReportIndustryStats{UnsubRate: 0.01},
+		Ecommerce:     ReportEcommerce{TotalRevenue: NewMoney(50, 0), CurrencyCode: "USD"},
+	}
+	b := &CampaignReport{
+		ID:            "b",
+		EmailsSent:    100,
+		Unsubscribed:  3,
+		Opens:         ReportOpens{UniqueOpens: 20},
+		IndustryStats: ReportIndustryStats{UnsubRate: 0.02},
+	}
+
+	benchmark := BenchmarkReports(industry, a, b)
+	assert.Len(t, benchmark.Rows, 2)
+
+	row := benchmark.Rows[0]
+	assert.Equal(t, 96, row.Delivered)
+	assert.InDelta(t, 0.5, row.OpenRate, 1e-9)
+	assert.InDelta(t, 0.3, row.OpenRateDelta, 1e-9)
+	assert.InDelta(t, 0.04, row.BounceRate, 1e-9)
+	assert.Equal(t, "0.50", row.RevenuePerRecipient.String())
+
+	total := benchmark.Total
+	assert.Equal(t, 200, total.EmailsSent)
+	assert.Equal(t, 196, total.Delivered)
+	assert.InDelta(t, 68.0/196, total.OpenRate, 1e-9)
+	assert.InDelta(t, 4.0/196-(0.01*96+0.02*100)/196, total.UnsubscribeRateDelta, 1e-9)
+	assert.Equal(t, "USD", total.CurrencyCode)
+
+	var buf bytes.Buffer
+	assert.NoError(t, benchmark.Write(&buf, ExportFormatCSV))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[3], "total,"))
+}
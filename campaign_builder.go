@@ -0,0 +1,181 @@
+package gochimp3
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// rollbackTimeout bounds deleting the draft after a failed build, which runs
+// even when the build's context is done.
+const rollbackTimeout = 30 * time.Second
+
+// CampaignBuilder chains the steps needed to create a campaign from a
+// template. Build creates the campaign, sets its content and optionally sends
+// a test email, deleting the draft again if any step after the create fails.
+type CampaignBuilder struct {
+	api     *API
+	request CampaignCreationRequest
+	content *CampaignContentTemplateRequest
+	test    *TestEmailRequest
+	err     error
+}
+
+// NewCampaign starts a campaign of one of the CampaignType* consts.
+func (api *API) NewCampaign(campaignType string) *CampaignBuilder {
+	b := &CampaignBuilder{api: api}
+	b.request.Type = campaignType
+	return b
+}
+
+// List sends the campaign to the list.
+func (b *CampaignBuilder) List(listID string) *CampaignBuilder {
+	b.request.Recipients.ListId = listID
+	return b
+}
+
+// SavedSegment narrows the recipients to a saved segment of the list.
+func (b *CampaignBuilder) SavedSegment(segmentID int) *CampaignBuilder {
+	b.request.Recipients.SegmentOptions.SavedSegmentId = segmentID
+	return b
+}
+
+// Segment narrows the recipients to the conditions of the segment builder.
+func (b *CampaignBuilder) Segment(segment *SegmentBuilder) *CampaignBuilder {
+	opts, err := segment.CampaignSegmentOptions()
+	if err != nil {
+		return b.fail(err)
+	}
+
+	opts.SavedSegmentId = b.request.Recipients.SegmentOptions.SavedSegmentId
+	b.request.Recipients.SegmentOptions = *opts
+	return b
+}
+
+// Settings replaces the campaign settings. A template set through Template is
+// kept.
+func (b *CampaignBuilder) Settings(settings CampaignCreationSettings) *CampaignBuilder {
+	if settings.TemplateId == 0 {
+		settings.TemplateId = b.request.Settings.TemplateId
+	}
+
+	b.request.Settings = settings
+	return b
+}
+
+func (b *CampaignBuilder) Tracking(tracking CampaignTracking) *CampaignBuilder {
+	b.request.Tracking = tracking
+	return b
+}
+
+// Template sets the template the content is built from.
+func (b *CampaignBuilder) Template(templateID uint) *CampaignBuilder {
+	b.request.Settings.TemplateId = templateID
+	if b.content == nil {
+		b.content = new(CampaignContentTemplateRequest)
+	}
+	b.content.ID = templateID
+	return b
+}
+
+// Section fills an mc:edit section of the template with HTML.
+func (b *CampaignBuilder) Section(name, html string) *CampaignBuilder {
+	if b.content == nil {
+		b.content = new(CampaignContentTemplateRequest)
+	}
+	if b.content.Sections == nil {
+		b.content.Sections = make(map[string]string)
+	}
+
+	b.content.Sections[name] = html
+	return b
+}
+
+func (b *CampaignBuilder) SocialCard(card SocialCard) *CampaignBuilder {
+	b.request.SocialCard = &card
+	return b
+}
+
+func (b *CampaignBuilder) RSS(opts RSSOptions) *CampaignBuilder {
+	b.request.RSSOptions = &opts
+	return b
+}
+
+func (b *CampaignBuilder) Variate(settings VariateSettings) *CampaignBuilder {
+	b.request.VariateSettings = &settings
+	return b
+}
+
+// TestEmail sends a test of one of the CampaignSendType* consts to the
+// addresses once the content is set.
+func (b *CampaignBuilder) TestEmail(sendType string, emails ...string) *CampaignBuilder {
+	b.test = &TestEmailRequest{TestEmails: emails, SendType: sendType}
+	return b
+}
+
+func (b *CampaignBuilder) fail(err error) *CampaignBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// Request returns the creation request built so far.
+func (b *CampaignBuilder) Request() (*CampaignCreationRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.request.Recipients.ListId == "" {
+		return nil, errors.New("campaign builder needs a list")
+	}
+	if b.content != nil && b.content.ID == 0 {
+		return nil, errors.New("campaign builder has sections but no template")
+	}
+
+	request := b.request
+	return &request, nil
+}
+
+// Build creates the campaign and runs the content and test steps.
+func (b *CampaignBuilder) Build(ctx context.Context) (*CampaignResponse, error) {
+	request, err := b.Request()
+	if err != nil {
+		return nil, err
+	}
+
+	campaign, err := b.api.CreateCampaign(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.finish(ctx, campaign); err != nil {
+		rollbackCtx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+		defer cancel()
+
+		if _, deleteErr := b.api.DeleteCampaign(rollbackCtx, campaign.ID); deleteErr != nil {
+			err = errors.WithSecondaryError(err, errors.Wrapf(deleteErr, "deleting draft campaign %s", campaign.ID))
+		}
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+func (b *CampaignBuilder) finish(ctx context.Context, campaign *CampaignResponse) error {
+	if b.content != nil {
+		content := &CampaignTemplateContentRequest{Template: b.content}
+		if _, err := b.api.UpdateCampaignTemplateContent(ctx, campaign.ID, content); err != nil {
+			return errors.Wrap(err, "setting campaign content")
+		}
+	}
+
+	if b.test != nil {
+		if _, err := b.api.SendTestEmail(ctx, campaign.ID, b.test); err != nil {
+			return errors.Wrap(err, "sending test email")
+		}
+	}
+
+	return nil
+}
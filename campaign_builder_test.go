@@ -0,0 +1,119 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignBuilderRequest(t *testing.T) {
+	api := testAPI()
+
+	_, err := api.NewCampaign(CampaignTypeRegular).Template(5).Request()
+	assert.Error(t, err, "no list")
+
+	_, err = api.NewCampaign(CampaignTypeRegular).List("l1").Section("body", "<p>Hi</p>").Request()
+	assert.Error(t, err, "sections without template")
+
+	_, err = api.NewCampaign(CampaignTypeRegular).List("l1").
+		Segment(NewSegmentBuilder("some").Where(NewVIPCondition(ConditionOpMember))).Request()
+	assert.Error(t, err, "invalid segment")
+
+	request, err := api.NewCampaign(CampaignTypeRegular).
+		List("l1").
+		SavedSegment(3).
+		Template(5).
+		Settings(CampaignCreationSettings{SubjectLine: "Hello"}).
+		Request()
+	fatalIf(t, err)
+	assert.Equal(t, "l1", request.Recipients.ListId)
+	assert.Equal(t, 3, request.Recipients.SegmentOptions.SavedSegmentId)
+	assert.Equal(t, uint(5), request.Settings.TemplateId)
+	assert.Equal(t, "Hello", request.Settings.SubjectLine)
+}
+
+func TestCampaignBuilderBuild(t *testing.T) {
+	var requests []string
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+
+		switch r.URL.Path {
+		case "/campaigns":
+			_, _ = fmt.Fprint(w, `{"id":"c1","type":"regular","status":"save"}`)
+		case "/campaigns/c1/content":
+			assert.JSONEq(t, `{"template":{"id":5,"sections":{"body":"<p>Hi</p>"}}}`, string(body))
+			_, _ = fmt.Fprint(w, `{"html":"<p>Hi</p>"}`)
+		case "/campaigns/c1/actions/test":
+			assert.JSONEq(t, `{"test_emails":["a@example.com"],"send_type":"html"}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	campaign, err := testAPI().NewCampaign(CampaignTypeRegular).
+		List("l1").
+		Template(5).
+		Section("body", "<p>Hi</p>").
+		TestEmail(CampaignSendTypeHtml, "a@example.com").
+		Build(context.Background())
+	fatalIf(t, err)
+
+	assert.Equal(t, "c1", campaign.ID)
+	assert.Equal(t, []string{
+		"POST /campaigns",
+		"PUT /campaigns/c1/content",
+		"POST /campaigns/c1/actions/test",
+	}, requests)
+}
+
+func TestCampaignBuilderRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests []string
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/campaigns":
+			_, _ = fmt.Fprint(w, `{"id":"c1","type":"regular","status":"save"}`)
+		case "/campaigns/c1/content":
+			// the build is given up while the content fails
+			cancel()
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"type":"error","title":"Invalid Resource","status":400}`)
+		case "/campaigns/c1":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	campaign, err := testAPI().NewCampaign(CampaignTypeRegular).
+		List("l1").
+		Template(5).
+		Build(ctx)
+	assert.Nil(t, campaign)
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"POST /campaigns",
+		"PUT /campaigns/c1/content",
+		"DELETE /campaigns/c1",
+	}, requests)
+}
+
+func TestUpdateCampaignTemplateContentRequiresBody(t *testing.T) {
+	api := testAPI()
+	api.LintContent = true
+
+	_, err := api.UpdateCampaignTemplateContent(context.Background(), "c1", nil)
+	assert.Error(t, err)
+}
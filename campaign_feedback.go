@@ -0,0 +1,169 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	campaignFeedbackPath       = singleCampaignPath + "/feedback"
+	singleCampaignFeedbackPath = campaignFeedbackPath + "/%d"
+
+	FeedbackSourceAPI     = "api"
+	FeedbackSourceEmail   = "email"
+	FeedbackSourceSMS     = "sms"
+	FeedbackSourceWeb     = "web"
+	FeedbackSourceIOS     = "ios"
+	FeedbackSourceAndroid = "android"
+)
+
+type CampaignFeedbackRequest struct {
+	Message    string `json:"message"`
+	BlockID    int    `json:"block_id,omitempty"`
+	IsComplete bool   `json:"is_complete"`
+}
+
+// CampaignFeedbackUpdateRequest only sends the fields that are set, so an
+// entry can be marked complete without repeating its message.
+type CampaignFeedbackUpdateRequest struct {
+	Message    *string `json:"message,omitempty"`
+	BlockID    *int    `json:"block_id,omitempty"`
+	IsComplete *bool   `json:"is_complete,omitempty"`
+}
+
+type CampaignFeedback struct {
+	withLinks
+
+	FeedbackID int    `json:"feedback_id"`
+	ParentID   int    `json:"parent_id"`
+	BlockID    int    `json:"block_id"`
+	Message    string `json:"message"`
+	IsComplete bool   `json:"is_complete"`
+	CreatedBy  string `json:"created_by"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+	Source     string `json:"source"` // one of the FeedbackSource* consts
+	CampaignID string `json:"campaign_id"`
+}
+
+type ListOfCampaignFeedback struct {
+	baseList
+
+	CampaignID string             `json:"campaign_id"`
+	Feedback   []CampaignFeedback `json:"feedback"`
+}
+
+// Unresolved returns the feedback entries that are not marked complete.
+func (list *ListOfCampaignFeedback) Unresolved() []CampaignFeedback {
+	var unresolved []CampaignFeedback
+	for _, feedback := range list.Feedback {
+		if !feedback.IsComplete {
+			unresolved = append(unresolved, feedback)
+		}
+	}
+
+	return unresolved
+}
+
+// UnresolvedFeedbackError is returned by SendCampaignIfResolved when the
+// campaign still has open feedback.
+type UnresolvedFeedbackError struct {
+	CampaignID string
+	Feedback   []CampaignFeedback
+}
+
+func (err *UnresolvedFeedbackError) Error() string {
+	return fmt.Sprintf("campaign %s has %d unresolved feedback entries", err.CampaignID, len(err.Feedback))
+}
+
+func (api *API) GetCampaignFeedback(ctx context.Context, id string, params *BasicQueryParams) (*ListOfCampaignFeedback, error) {
+	endpoint := fmt.Sprintf(campaignFeedbackPath, id)
+	response := new(ListOfCampaignFeedback)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) GetCampaignFeedbackEntry(ctx context.Context, id string, feedbackID int, params *BasicQueryParams) (*CampaignFeedback, error) {
+	endpoint := fmt.Sprintf(singleCampaignFeedbackPath, id, feedbackID)
+	response := new(CampaignFeedback)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) CreateCampaignFeedback(ctx context.Context, id string, body *CampaignFeedbackRequest) (*CampaignFeedback, error) {
+	endpoint := fmt.Sprintf(campaignFeedbackPath, id)
+	response := new(CampaignFeedback)
+	return response, api.Request(ctx, http.MethodPost, endpoint, nil, body, response)
+}
+
+func (api *API) UpdateCampaignFeedback(ctx context.Context, id string, feedbackID int, body *CampaignFeedbackUpdateRequest) (*CampaignFeedback, error) {
+	endpoint := fmt.Sprintf(singleCampaignFeedbackPath, id, feedbackID)
+	response := new(CampaignFeedback)
+	return response, api.Request(ctx, http.MethodPatch, endpoint, nil, body, response)
+}
+
+func (api *API) DeleteCampaignFeedback(ctx context.Context, id string, feedbackID int) (bool, error) {
+	endpoint := fmt.Sprintf(singleCampaignFeedbackPath, id, feedbackID)
+	return api.RequestOk(ctx, http.MethodDelete, endpoint)
+}
+
+// SendCampaignIfResolved sends the campaign only when all its feedback is
+// marked complete, otherwise an *UnresolvedFeedbackError is returned.
+func (api *API) SendCampaignIfResolved(ctx context.Context, id string) (bool, error) {
+	feedback, err := api.GetCampaignFeedback(ctx, id, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if unresolved := feedback.Unresolved(); len(unresolved) > 0 {
+		return false, &UnresolvedFeedbackError{CampaignID: id, Feedback: unresolved}
+	}
+
+	return api.SendCampaign(ctx, id, &SendCampaignRequest{CampaignId: id})
+}
+
+func (campaign *CampaignResponse) GetFeedback(ctx context.Context, params *BasicQueryParams) (*ListOfCampaignFeedback, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetCampaignFeedback(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetFeedbackEntry(ctx context.Context, feedbackID int, params *BasicQueryParams) (*CampaignFeedback, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetCampaignFeedbackEntry(ctx, campaign.ID, feedbackID, params)
+}
+
+func (campaign *CampaignResponse) CreateFeedback(ctx context.Context, body *CampaignFeedbackRequest) (*CampaignFeedback, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.CreateCampaignFeedback(ctx, campaign.ID, body)
+}
+
+func (campaign *CampaignResponse) UpdateFeedback(ctx context.Context, feedbackID int, body *CampaignFeedbackUpdateRequest) (*CampaignFeedback, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.UpdateCampaignFeedback(ctx, campaign.ID, feedbackID, body)
+}
+
+func (campaign *CampaignResponse) DeleteFeedback(ctx context.Context, feedbackID int) (bool, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return false, err
+	}
+
+	return campaign.api.DeleteCampaignFeedback(ctx, campaign.ID, feedbackID)
+}
+
+// SendIfResolved is Send, refusing while unresolved feedback exists.
+func (campaign *CampaignResponse) SendIfResolved(ctx context.Context) (bool, error) {
+	return campaign.perform(CampaignActionSend, func() (bool, error) {
+		return campaign.api.SendCampaignIfResolved(ctx, campaign.ID)
+	})
+}
@@ -0,0 +1,84 @@
+package gochimp3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignFeedbackEndpoints(t *testing.T) {
+	ctx := context.Background()
+	campaign := &CampaignResponse{ID: "c1", api: testAPI()}
+
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/campaigns/c1/feedback", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"message":"Fix the logo","block_id":3,"is_complete":false}`, string(body))
+		_, _ = fmt.Fprint(w, `{"feedback_id":7,"block_id":3,"message":"Fix the logo","campaign_id":"c1"}`)
+	}
+	created, err := campaign.CreateFeedback(ctx, &CampaignFeedbackRequest{Message: "Fix the logo", BlockID: 3})
+	fatalIf(t, err)
+	assert.Equal(t, 7, created.FeedbackID)
+
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/campaigns/c1/feedback/7", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"is_complete":true}`, string(body))
+		_, _ = fmt.Fprint(w, `{"feedback_id":7,"message":"Fix the logo","is_complete":true}`)
+	}
+	complete := true
+	updated, err := campaign.UpdateFeedback(ctx, 7, &CampaignFeedbackUpdateRequest{IsComplete: &complete})
+	fatalIf(t, err)
+	assert.True(t, updated.IsComplete)
+	assert.Equal(t, "Fix the logo", updated.Message)
+
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/campaigns/c1/feedback/7", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+	ok, err := campaign.DeleteFeedback(ctx, 7)
+	fatalIf(t, err)
+	assert.True(t, ok)
+}
+
+func TestSendCampaignIfResolved(t *testing.T) {
+	ctx := context.Background()
+	api := testAPI()
+
+	var sent bool
+	feedback := `{"feedback":[{"feedback_id":1,"is_complete":true},{"feedback_id":2,"is_complete":false}],"total_items":2}`
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/campaigns/c1/feedback":
+			_, _ = fmt.Fprint(w, feedback)
+		case "/campaigns/c1/actions/send":
+			sent = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+
+	ok, err := api.SendCampaignIfResolved(ctx, "c1")
+	assert.False(t, ok)
+	var unresolved *UnresolvedFeedbackError
+	if assert.True(t, errors.As(err, &unresolved)) {
+		assert.Len(t, unresolved.Feedback, 1)
+		assert.Equal(t, 2, unresolved.Feedback[0].FeedbackID)
+	}
+	assert.False(t, sent)
+
+	feedback = `{"feedback":[{"feedback_id":1,"is_complete":true}],"total_items":1}`
+	ok, err = api.SendCampaignIfResolved(ctx, "c1")
+	fatalIf(t, err)
+	assert.True(t, ok)
+	assert.True(t, sent)
+}
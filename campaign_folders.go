@@ -2,12 +2,15 @@ package gochimp3
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+
+	"github.com/cockroachdb/errors"
 )
 
 const (
-	campaignFoldersPath = "/campaign-folders"
-	// single folder endpoint not implemented
+	campaignFoldersPath      = "/campaign-folders"
+	singleCampaignFolderPath = campaignFoldersPath + "/%s"
 )
 
 type CampaignFolderQueryParams struct {
@@ -29,6 +32,14 @@ type CampaignFolder struct {
 	api *API
 }
 
+func (folder *CampaignFolder) CanMakeRequest() error {
+	if folder.ID == "" {
+		return errors.New("No ID provided on campaign folder")
+	}
+
+	return nil
+}
+
 type CampaignFolderCreationRequest struct {
 	Name string `json:"name"`
 }
@@ -41,8 +52,8 @@ func (api *API) GetCampaignFolders(ctx context.Context, params *CampaignFolderQu
 		return nil, err
 	}
 
-	for _, l := range response.Folders {
-		l.api = api
+	for i := range response.Folders {
+		response.Folders[i].api = api
 	}
 
 	return response, nil
@@ -53,3 +64,135 @@ func (api *API) CreateCampaignFolder(ctx context.Context, body *CampaignFolderCr
 	response.api = api
 	return response, api.Request(ctx, http.MethodPost, campaignFoldersPath, nil, body, response)
 }
+
+func (api *API) GetCampaignFolder(ctx context.Context, id string, params *BasicQueryParams) (*CampaignFolder, error) {
+	endpoint := fmt.Sprintf(singleCampaignFolderPath, id)
+
+	response := new(CampaignFolder)
+	response.api = api
+
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) UpdateCampaignFolder(ctx context.Context, id string, body *CampaignFolderCreationRequest) (*CampaignFolder, error) {
+	endpoint := fmt.Sprintf(singleCampaignFolderPath, id)
+
+	response := new(CampaignFolder)
+	response.api = api
+
+	return response, api.Request(ctx, http.MethodPatch, endpoint, nil, body, response)
+}
+
+func (api *API) DeleteCampaignFolder(ctx context.Context, id string) (bool, error) {
+	endpoint := fmt.Sprintf(singleCampaignFolderPath, id)
+	return api.RequestOk(ctx, http.MethodDelete, endpoint)
+}
+
+// FindCampaignFolder returns the first folder with the given name, or nil if
+// there is none.
+func (api *API) FindCampaignFolder(ctx context.Context, name string) (*CampaignFolder, error) {
+	params := new(CampaignFolderQueryParams)
+	params.Count = maxPageSize
+
+	for {
+		folders, err := api.GetCampaignFolders(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range folders.Folders {
+			if folders.Folders[i].Name == name {
+				return &folders.Folders[i], nil
+			}
+		}
+
+		params.Offset += len(folders.Folders)
+		if len(folders.Folders) == 0 || params.Offset >= folders.TotalItems {
+			return nil, nil
+		}
+	}
+}
+
+// EnsureCampaignFolder returns the folder with the given name, creating it if
+// it does not exist yet.
+func (api *API) EnsureCampaignFolder(ctx context.Context, name string) (*CampaignFolder, error) {
+	folder, err := api.FindCampaignFolder(ctx, name)
+	if err != nil || folder != nil {
+		return folder, err
+	}
+
+	return api.CreateCampaignFolder(ctx, &CampaignFolderCreationRequest{Name: name})
+}
+
+// Rename changes the folder name in place.
+func (folder *CampaignFolder) Rename(ctx context.Context, name string) error {
+	if err := folder.CanMakeRequest(); err != nil {
+		return err
+	}
+
+	response, err := folder.api.UpdateCampaignFolder(ctx, folder.ID, &CampaignFolderCreationRequest{Name: name})
+	if err != nil {
+		return err
+	}
+
+	folder.Name = response.Name
+	return nil
+}
+
+func (folder *CampaignFolder) Delete(ctx context.Context) (bool, error) {
+	if err := folder.CanMakeRequest(); err != nil {
+		return false, err
+	}
+
+	return folder.api.DeleteCampaignFolder(ctx, folder.ID)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Moving
+// ------------------------------------------------------------------------------------------------
+
+// FolderMoveResult is the outcome of moving a single campaign or template.
+type FolderMoveResult struct {
+	ID    string
+	Error error
+}
+
+// campaignFolderUpdate patches only the folder of a campaign, leaving its
+// other settings untouched.
+type campaignFolderUpdate struct {
+	Settings struct {
+		FolderID string `json:"folder_id"`
+	} `json:"settings"`
+}
+
+// MoveCampaigns moves the campaigns into the folder, use an empty folder ID to
+// move them out of any folder. Failures are reported per campaign; the
+// returned error is only set when the context is done.
+func (api *API) MoveCampaigns(ctx context.Context, folderID string, campaignIDs ...string) ([]FolderMoveResult, error) {
+	results := make([]FolderMoveResult, 0, len(campaignIDs))
+	for _, id := range campaignIDs {
+		if err := ctx.Err(); err != nil {
+			return results, errors.WithStack(err)
+		}
+
+		results = append(results, FolderMoveResult{ID: id, Error: api.moveCampaign(ctx, folderID, id)})
+	}
+
+	return results, nil
+}
+
+func (api *API) moveCampaign(ctx context.Context, folderID, id string) error {
+	body := new(campaignFolderUpdate)
+	body.Settings.FolderID = folderID
+
+	endpoint := fmt.Sprintf(singleCampaignPath, id)
+	return api.Request(ctx, http.MethodPatch, endpoint, nil, body, nil)
+}
+
+func (folder *CampaignFolder) MoveCampaigns(ctx context.Context, campaignIDs ...string) ([]FolderMoveResult, error) {
+	if err := folder.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return folder.api.MoveCampaigns(ctx, folder.ID, campaignIDs...)
+}
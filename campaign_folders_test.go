@@ -0,0 +1,129 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveCampaigns(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"settings":{"folder_id":"f1"}}`, string(body))
+
+		if r.URL.Path == "/campaigns/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"type":"error","title":"Resource Not Found","status":404}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"id":"c1"}`)
+	}
+
+	folder := &CampaignFolder{ID: "f1", api: testAPI()}
+	results, err := folder.MoveCampaigns(context.Background(), "c1", "missing", "c2")
+	fatalIf(t, err)
+
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "c1", results[0].ID)
+		assert.NoError(t, results[0].Error)
+		assert.True(t, isNotFound(results[1].Error))
+		assert.NoError(t, results[2].Error)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = folder.MoveCampaigns(ctx, "c1")
+	assert.Error(t, err)
+	assert.Empty(t, results)
+}
+
+func TestFindCampaignFolder(t *testing.T) {
+	var created bool
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/campaign-folders", r.URL.Path)
+		if r.Method == http.MethodPost {
+			created = true
+			_, _ = fmt.Fprint(w, `{"id":"f9","name":"Archive"}`)
+			return
+		}
+
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"folders":[{"id":"f1","name":"News"},{"id":"f2","name":"Promo"}],"total_items":3}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"folders":[{"id":"f3","name":"Drafts"}],"total_items":3}`)
+		}
+	}
+
+	api := testAPI()
+	ctx := context.Background()
+
+	folder, err := api.FindCampaignFolder(ctx, "Drafts")
+	fatalIf(t, err)
+	assert.Equal(t, "f3", folder.ID)
+
+	folder, err = api.FindCampaignFolder(ctx, "drafts")
+	fatalIf(t, err)
+	assert.Nil(t, folder)
+
+	folder, err = api.EnsureCampaignFolder(ctx, "News")
+	fatalIf(t, err)
+	assert.Equal(t, "f1", folder.ID)
+	assert.False(t, created)
+
+	folder, err = api.EnsureCampaignFolder(ctx, "Archive")
+	fatalIf(t, err)
+	assert.Equal(t, "f9", folder.ID)
+	assert.True(t, created)
+}
+
+func TestTemplateFolders(t *testing.T) {
+	var moved []string
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/template-folders" && r.Method == http.MethodGet:
+			_, _ = fmt.Fprint(w, `{"folders":[{"id":"t1","name":"Layouts"}],"total_items":1}`)
+		case r.URL.Path == "/template-folders" && r.Method == http.MethodPost:
+			_, _ = fmt.Fprint(w, `{"id":"t2","name":"Seasonal"}`)
+		case r.URL.Path == "/templates/10" && r.Method == http.MethodGet:
+			_, _ = fmt.Fprint(w, `{"id":10,"name":"Welcome"}`)
+		case r.URL.Path == "/templates/10/default-content":
+			assert.Equal(t, "html", r.URL.Query().Get("fields"))
+			_, _ = fmt.Fprint(w, `{"html":"<p>Hi</p>"}`)
+		case r.URL.Path == "/templates/11/default-content":
+			_, _ = fmt.Fprint(w, `{"sections":{}}`)
+		case strings.HasPrefix(r.URL.Path, "/templates/") && r.Method == http.MethodGet:
+			_, _ = fmt.Fprint(w, `{"id":11,"name":"Legacy"}`)
+		case r.URL.Path == "/templates/10" && r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name":"Welcome","html":"<p>Hi</p>","folder_id":"t1"}`, string(body))
+			moved = append(moved, "10")
+			_, _ = fmt.Fprint(w, `{"id":10,"name":"Welcome"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+
+	api := testAPI()
+	ctx := context.Background()
+
+	folder, err := api.EnsureTemplateFolder(ctx, "Layouts")
+	fatalIf(t, err)
+	assert.Equal(t, "t1", folder.ID)
+
+	created, err := api.EnsureTemplateFolder(ctx, "Seasonal")
+	fatalIf(t, err)
+	assert.Equal(t, "t2", created.ID)
+
+	results, err := folder.MoveTemplates(ctx, "10", "11")
+	fatalIf(t, err)
+	assert.NoError(t, results[0].Error)
+	assert.Error(t, results[1].Error)
+	assert.Equal(t, []string{"10"}, moved)
+}
@@ -0,0 +1,296 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	PreflightSeverityError   = "error"
+	PreflightSeverityWarning = "warning"
+	PreflightSeverityInfo    = "info"
+
+	PreflightSourceSettings   = "settings"
+	PreflightSourceRecipients = "recipients"
+	PreflightSourceContent    = "content"
+	PreflightSourceChecklist  = "checklist"
+)
+
+type PreflightIssue struct {
+	Severity string // one of the PreflightSeverity* consts
+	Source   string // one of the PreflightSource* consts
+	Heading  string
+	Details  string
+}
+
+type PreflightReport struct {
+	CampaignID string
+	Ready      bool
+	Issues     []PreflightIssue
+}
+
+func (report *PreflightReport) Errors() []PreflightIssue {
+	return report.filter(PreflightSeverityError)
+}
+
+func (report *PreflightReport) Warnings() []PreflightIssue {
+	return report.filter(PreflightSeverityWarning)
+}
+
+func (report *PreflightReport) filter(severity string) []PreflightIssue {
+	var issues []PreflightIssue
+	for _, issue := range report.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+func (report *PreflightReport) add(issues ...PreflightIssue) {
+	for _, issue := range issues {
+		if issue.Severity == PreflightSeverityError {
+			report.Ready = false
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+}
+
+// CheckCampaignSettings validates the settings Mailchimp requires before a
+// campaign can be sent.
+func CheckCampaignSettings(settings *CampaignCreationSettings) []PreflightIssue {
+	var issues []PreflightIssue
+	missing := func(heading string) {
+		issues = append(issues, PreflightIssue{
+			Severity: PreflightSeverityError,
+			Source:   PreflightSourceSettings,
+			Heading:  heading,
+		})
+	}
+
+	if strings.TrimSpace(settings.SubjectLine) == "" {
+		missing("Missing subject line")
+	}
+	if strings.TrimSpace(settings.FromName) == "" {
+		missing("Missing from name")
+	}
+
+	if strings.TrimSpace(settings.ReplyTo) == "" {
+		missing("Missing reply-to address")
+	} else if err := checkBareAddress(settings.ReplyTo); err != nil {
+		issues = append(issues, PreflightIssue{
+			Severity: PreflightSeverityError,
+			Source:   PreflightSourceSettings,
+			Heading:  "Invalid reply-to address",
+			Details:  fmt.Sprintf("%q: %v", settings.ReplyTo, err),
+		})
+	}
+
+	if strings.TrimSpace(settings.PreviewText) == "" {
+		issues = append(issues, PreflightIssue{
+			Severity: PreflightSeverityInfo,
+			Source:   PreflightSourceSettings,
+			Heading:  "No preview text",
+		})
+	}
+
+	return issues
+}
+
+// checkBareAddress accepts a plain email address only, Mailchimp rejects the
+// "Name <address>" form mail.ParseAddress allows.
+func checkBareAddress(address string) error {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if parsed.Name != "" || parsed.Address != strings.TrimSpace(address) {
+		return errors.Errorf("must be a bare address like %s", parsed.Address)
+	}
+
+	return nil
+}
+
+// CheckCampaignContent looks for content problems that would make Mailchimp
+// refuse to send a campaign of the given type. Plaintext campaigns only have
+// plain-text content, other campaigns need HTML and get a plain-text version
+// generated when it is empty. Merge tags are checked against the list's merge
+// fields.
+func CheckCampaignContent(campaignType, html, plainText string, mergeFields []string) []PreflightIssue {
+	var issues []PreflightIssue
+	issue := func(severity, heading, details string) {
+		issues = append(issues, PreflightIssue{
+			Severity: severity,
+			Source:   PreflightSourceContent,
+			Heading:  heading,
+			Details:  details,
+		})
+	}
+
+	contents := []struct{ name, body string }{{"HTML", html}, {"plain-text", plainText}}
+	if campaignType == CampaignTypePlaintext {
+		contents = contents[1:]
+
+		if strings.TrimSpace(plainText) == "" {
+			issue(PreflightSeverityError, "Missing plain-text content", "")
+		} else if !strings.Contains(strings.ToUpper(plainText), "*|UNSUB|*") {
+			issue(PreflightSeverityError, "Missing *|UNSUB|* merge tag", "Every campaign must contain an unsubscribe link.")
+		}
+	} else {
+		if strings.TrimSpace(html) == "" {
+			issue(PreflightSeverityError, "Missing HTML content", "")
+		} else if !strings.Contains(strings.ToUpper(html), "*|UNSUB|*") {
+			issue(PreflightSeverityError, "Missing *|UNSUB|* merge tag", "Every campaign must contain an unsubscribe link.")
+		}
+
+		if strings.TrimSpace(plainText) == "" {
+			issue(PreflightSeverityWarning, "Empty plain-text content", "")
+		}
+	}
+
+	for _, content := range contents {
+		for _, lint := range lintMergeTags(content.body, mergeFields, nil) {
+			issue(PreflightSeverityError, "Merge tag problem", content.name+" content "+lint.String())
+		}
+	}
+
+	return issues
+}
+
+// PreflightCampaign combines Mailchimp's send checklist with local checks of
+// the campaign settings, recipients and content into a single report.
+func (api *API) PreflightCampaign(ctx context.Context, id string) (*PreflightReport, error) {
+	campaign, err := api.GetCampaign(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PreflightReport{CampaignID: id, Ready: true}
+
+	checklist, err := api.GetSendChecklist(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range checklist.Items {
+		severity := PreflightSeverityWarning
+		switch item.Type {
+		case ChecklistItemSuccess:
+			continue
+		case ChecklistItemError:
+			severity = PreflightSeverityError
+		}
+
+		report.add(PreflightIssue{
+			Severity: severity,
+			Source:   PreflightSourceChecklist,
+			Heading:  item.Heading,
+			Details:  item.Details,
+		})
+	}
+	if !checklist.IsReady {
+		report.Ready = false
+	}
+
+	settings := campaign.Settings.CreationSettings()
+	report.add(CheckCampaignSettings(&settings)...)
+
+	list, issues, err := api.checkCampaignRecipients(ctx, campaign)
+	if err != nil {
+		return nil, err
+	}
+	report.add(issues...)
+
+	content, err := api.GetCampaignContent(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var mergeFields []string
+	if list != nil {
+		params := new(MergeFieldsParams)
+		params.Count = maxPageSize
+
+		fields, err := list.GetMergeFields(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeFields = make([]string, 0, len(fields.MergeFields))
+		for _, field := range fields.MergeFields {
+			mergeFields = append(mergeFields, field.Tag)
+		}
+	}
+	report.add(CheckCampaignContent(campaign.Type, content.Html, content.PlainText, mergeFields)...)
+
+	return report, nil
+}
+
+func (campaign *CampaignResponse) Preflight(ctx context.Context) (*PreflightReport, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.PreflightCampaign(ctx, campaign.ID)
+}
+
+// checkCampaignRecipients verifies the campaign's list and saved segment still
+// exist and are not empty. The list is returned when it could be found.
+func (api *API) checkCampaignRecipients(ctx context.Context, campaign *CampaignResponse) (*ListResponse, []PreflightIssue, error) {
+	recipientIssue := func(severity, heading, details string) []PreflightIssue {
+		return []PreflightIssue{{
+			Severity: severity,
+			Source:   PreflightSourceRecipients,
+			Heading:  heading,
+			Details:  details,
+		}}
+	}
+
+	if campaign.Recipients.ListId == "" {
+		return nil, recipientIssue(PreflightSeverityError, "No list selected", ""), nil
+	}
+
+	list, err := api.GetList(ctx, campaign.Recipients.ListId, nil)
+	if isNotFound(err) {
+		return nil, recipientIssue(PreflightSeverityError, "List not found",
+			fmt.Sprintf("List %s no longer exists.", campaign.Recipients.ListId)), nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	var issues []PreflightIssue
+	if list.Stats.MemberCount == 0 {
+		issues = append(issues, recipientIssue(PreflightSeverityError, "List has no subscribers",
+			fmt.Sprintf("List %q has no subscribed members.", list.Name))...)
+	}
+
+	segmentID := campaign.Recipients.SegmentOptions.SavedSegmentId
+	if segmentID == 0 {
+		return list, issues, nil
+	}
+
+	segment, err := list.GetSegment(ctx, strconv.Itoa(segmentID), nil)
+	if isNotFound(err) {
+		issues = append(issues, recipientIssue(PreflightSeverityError, "Segment not found",
+			fmt.Sprintf("Saved segment %d no longer exists on list %q.", segmentID, list.Name))...)
+	} else if err != nil {
+		return nil, nil, err
+	} else if segment.MemberCount == 0 {
+		issues = append(issues, recipientIssue(PreflightSeverityError, "Segment is empty",
+			fmt.Sprintf("Segment %q has no members.", segment.Name))...)
+	}
+
+	return list, issues, nil
+}
+
+func isNotFound(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.Status == http.StatusNotFound
+}
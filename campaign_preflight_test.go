@@ -0,0 +1,96 @@
+package gochimp3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCampaignContent(t *testing.T) {
+	tests := map[string]struct {
+		campaignType string
+		html         string
+		plainText    string
+		headings     []string
+	}{
+		"regular": {
+			campaignType: CampaignTypeRegular,
+			html:         `<p>Hi *|FNAME|*</p><a href="*|UNSUB|*">unsubscribe</a>`,
+			plainText:    "Hi *|FNAME|*\n*|UNSUB|*",
+		},
+		"regular without plain text": {
+			campaignType: CampaignTypeRegular,
+			html:         `<a href="*|UNSUB|*">unsubscribe</a>`,
+			headings:     []string{"Empty plain-text content"},
+		},
+		"regular without html": {
+			campaignType: CampaignTypeRegular,
+			plainText:    "*|UNSUB|*",
+			headings:     []string{"Missing HTML content"},
+		},
+		"regular missing unsub": {
+			campaignType: CampaignTypeVariate,
+			html:         `<p>Hi</p>`,
+			plainText:    "Hi",
+			headings:     []string{"Missing *|UNSUB|* merge tag"},
+		},
+		"plaintext": {
+			campaignType: CampaignTypePlaintext,
+			plainText:    "Hi *|FNAME|*\nUnsubscribe: *|unsub|*",
+		},
+		"plaintext ignores html": {
+			campaignType: CampaignTypePlaintext,
+			html:         "<p>*|NOPE|*</p>",
+			plainText:    "*|UNSUB|*",
+		},
+		"plaintext missing unsub": {
+			campaignType: CampaignTypePlaintext,
+			html:         `<a href="*|UNSUB|*">unsubscribe</a>`,
+			plainText:    "Hi",
+			headings:     []string{"Missing *|UNSUB|* merge tag"},
+		},
+		"plaintext empty": {
+			campaignType: CampaignTypePlaintext,
+			headings:     []string{"Missing plain-text content"},
+		},
+		"unknown merge tag": {
+			campaignType: CampaignTypePlaintext,
+			plainText:    "Hi *|NICKNAME|* *|UNSUB|*",
+			headings:     []string{"Merge tag problem"},
+		},
+	}
+
+	for name, test := range tests {
+		var headings []string
+		for _, issue := range CheckCampaignContent(test.campaignType, test.html, test.plainText, []string{"FNAME"}) {
+			headings = append(headings, issue.Heading)
+		}
+		assert.Equal(t, test.headings, headings, name)
+	}
+}
+
+func TestCheckCampaignSettingsReplyTo(t *testing.T) {
+	tests := map[string]bool{
+		"news@example.com":          true,
+		" news@example.com ":        true,
+		"News <news@example.com>":   false,
+		"<news@example.com>":        false,
+		"news":                      false,
+		"news@example.com, a@b.com": false,
+	}
+
+	for replyTo, valid := range tests {
+		settings := &CampaignCreationSettings{
+			SubjectLine: "Hello",
+			FromName:    "News",
+			ReplyTo:     replyTo,
+			PreviewText: "Hi",
+		}
+		issues := CheckCampaignSettings(settings)
+		if valid {
+			assert.Empty(t, issues, replyTo)
+		} else if assert.Len(t, issues, 1, replyTo) {
+			assert.Equal(t, "Invalid reply-to address", issues[0].Heading, replyTo)
+		}
+	}
+}
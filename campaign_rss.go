@@ -0,0 +1,117 @@
+package gochimp3
+
+import (
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	RSSFrequencyDaily   = "daily"
+	RSSFrequencyWeekly  = "weekly"
+	RSSFrequencyMonthly = "monthly"
+
+	WeekdaySunday    = "sunday"
+	WeekdayMonday    = "monday"
+	WeekdayTuesday   = "tuesday"
+	WeekdayWednesday = "wednesday"
+	WeekdayThursday  = "thursday"
+	WeekdayFriday    = "friday"
+	WeekdaySaturday  = "saturday"
+)
+
+var weekdays = []string{
+	WeekdaySunday, WeekdayMonday, WeekdayTuesday, WeekdayWednesday,
+	WeekdayThursday, WeekdayFriday, WeekdaySaturday,
+}
+
+type RSSOptions struct {
+	FeedURL         string      `json:"feed_url"`
+	Frequency       string      `json:"frequency"` // one of the RSSFrequency* consts
+	Schedule        RSSSchedule `json:"schedule"`
+	ConstrainRSSImg bool        `json:"constrain_rss_img"`
+}
+
+type RSSSchedule struct {
+	// Hour is the hour of the day, 0-23, the campaign is sent at.
+	Hour int `json:"hour"`
+
+	// DailySend selects the days a daily campaign is sent on.
+	DailySend *RSSDailySend `json:"daily_send,omitempty"`
+
+	// WeeklySendDay is one of the Weekday* consts, used by weekly campaigns.
+	WeeklySendDay string `json:"weekly_send_day,omitempty"`
+
+	// MonthlySendDate is the day of the month, 1-31, a monthly campaign is
+	// sent on. Use 0 for the last day of the month.
+	MonthlySendDate *int `json:"monthly_send_date,omitempty"`
+}
+
+type RSSDailySend struct {
+	Sunday    bool `json:"sunday"`
+	Monday    bool `json:"monday"`
+	Tuesday   bool `json:"tuesday"`
+	Wednesday bool `json:"wednesday"`
+	Thursday  bool `json:"thursday"`
+	Friday    bool `json:"friday"`
+	Saturday  bool `json:"saturday"`
+}
+
+// RSSEveryDay returns a daily schedule sending on all days of the week.
+func RSSEveryDay() *RSSDailySend {
+	return &RSSDailySend{true, true, true, true, true, true, true}
+}
+
+// RSSWeekdays returns a daily schedule sending Monday through Friday.
+func RSSWeekdays() *RSSDailySend {
+	return &RSSDailySend{Monday: true, Tuesday: true, Wednesday: true, Thursday: true, Friday: true}
+}
+
+func (days *RSSDailySend) any() bool {
+	return days.Sunday || days.Monday || days.Tuesday || days.Wednesday ||
+		days.Thursday || days.Friday || days.Saturday
+}
+
+type RSSOptionsResponse struct {
+	RSSOptions
+
+	LastSent string `json:"last_sent"`
+}
+
+func (opts *RSSOptions) Validate() error {
+	if opts == nil {
+		return nil
+	}
+
+	feed, err := url.Parse(opts.FeedURL)
+	if err != nil || feed.Host == "" || (feed.Scheme != "http" && feed.Scheme != "https") {
+		return errors.Errorf("invalid RSS feed URL %q", opts.FeedURL)
+	}
+
+	schedule := opts.Schedule
+	if schedule.Hour < 0 || schedule.Hour > 23 {
+		return errors.Errorf("RSS schedule hour must be between 0 and 23, got %d", schedule.Hour)
+	}
+
+	switch opts.Frequency {
+	case RSSFrequencyDaily:
+		if schedule.DailySend == nil || !schedule.DailySend.any() {
+			return errors.New("daily RSS campaigns need at least one send day")
+		}
+	case RSSFrequencyWeekly:
+		if !containsString(weekdays, schedule.WeeklySendDay) {
+			return errors.Errorf("invalid RSS weekly send day %q", schedule.WeeklySendDay)
+		}
+	case RSSFrequencyMonthly:
+		if schedule.MonthlySendDate == nil {
+			return errors.New("monthly RSS campaigns need a send date")
+		}
+		if date := *schedule.MonthlySendDate; date < 0 || date > 31 {
+			return errors.Errorf("RSS monthly send date must be between 0 and 31, got %d", date)
+		}
+	default:
+		return errors.Errorf("invalid RSS frequency %q", opts.Frequency)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package gochimp3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRSSOptionsValidate(t *testing.T) {
+	date := func(day int) *int { return &day }
+	feed := "https://example.com/feed.xml"
+
+	tests := map[string]struct {
+		opts  RSSOptions
+		valid bool
+	}{
+		"daily every day":     {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyDaily, Schedule: RSSSchedule{Hour: 9, DailySend: RSSEveryDay()}}, true},
+		"daily weekdays":      {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyDaily, Schedule: RSSSchedule{DailySend: RSSWeekdays()}}, true},
+		"daily without days":  {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyDaily}, false},
+		"daily no day set":    {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyDaily, Schedule: RSSSchedule{DailySend: &RSSDailySend{}}}, false},
+		"weekly":              {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyWeekly, Schedule: RSSSchedule{Hour: 23, WeeklySendDay: WeekdayFriday}}, true},
+		"weekly without day":  {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyWeekly}, false},
+		"weekly unknown day":  {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyWeekly, Schedule: RSSSchedule{WeeklySendDay: "Friday"}}, false},
+		"monthly":             {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyMonthly, Schedule: RSSSchedule{MonthlySendDate: date(15)}}, true},
+		"monthly last day":    {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyMonthly, Schedule: RSSSchedule{MonthlySendDate: date(0)}}, true},
+		"monthly 31st":        {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyMonthly, Schedule: RSSSchedule{MonthlySendDate: date(31)}}, true},
+		"monthly without day": {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyMonthly}, false},
+		"monthly 32nd":        {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyMonthly, Schedule: RSSSchedule{MonthlySendDate: date(32)}}, false},
+		"monthly negative":    {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyMonthly, Schedule: RSSSchedule{MonthlySendDate: date(-1)}}, false},
+		"hour too late":       {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyWeekly, Schedule: RSSSchedule{Hour: 24, WeeklySendDay: WeekdayMonday}}, false},
+		"negative hour":       {RSSOptions{FeedURL: feed, Frequency: RSSFrequencyWeekly, Schedule: RSSSchedule{Hour: -1, WeeklySendDay: WeekdayMonday}}, false},
+		"unknown frequency":   {RSSOptions{FeedURL: feed, Frequency: "hourly"}, false},
+		"missing feed":        {RSSOptions{Frequency: RSSFrequencyWeekly, Schedule: RSSSchedule{WeeklySendDay: WeekdayMonday}}, false},
+		"relative feed":       {RSSOptions{FeedURL: "/feed.xml", Frequency: RSSFrequencyWeekly, Schedule: RSSSchedule{WeeklySendDay: WeekdayMonday}}, false},
+		"ftp feed":            {RSSOptions{FeedURL: "ftp://example.com/feed", Frequency: RSSFrequencyWeekly, Schedule: RSSSchedule{WeeklySendDay: WeekdayMonday}}, false},
+	}
+
+	for name, test := range tests {
+		if test.valid {
+			assert.NoError(t, test.opts.Validate(), name)
+		} else {
+			assert.Error(t, test.opts.Validate(), name)
+		}
+	}
+
+	var opts *RSSOptions
+	assert.NoError(t, opts.Validate())
+}
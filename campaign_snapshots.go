@@ -0,0 +1,416 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	json "github.com/json-iterator/go"
+)
+
+const snapshotIDFormat = "20060102T150405.000000000Z"
+
+// CampaignSnapshot is a point in time copy of a campaign's content and
+// settings.
+type CampaignSnapshot struct {
+	ID          string                   `json:"id"`
+	CampaignID  string                   `json:"campaign_id"`
+	TakenAt     time.Time                `json:"taken_at"`
+	Settings    CampaignResponseSettings `json:"settings"`
+	PlainText   string                   `json:"plain_text"`
+	Html        string                   `json:"html"`
+	ArchiveHtml string                   `json:"archive_html"`
+}
+
+// SnapshotStore persists campaign snapshots. List returns the snapshot IDs of
+// a campaign, oldest first.
+type SnapshotStore interface {
+	Save(ctx context.Context, snapshot *CampaignSnapshot) error
+	Load(ctx context.Context, campaignID, snapshotID string) (*CampaignSnapshot, error)
+	List(ctx context.Context, campaignID string) ([]string, error)
+}
+
+// FileSnapshotStore keeps every snapshot as a JSON file in a directory per
+// campaign below Dir.
+type FileSnapshotStore struct {
+	Dir string
+}
+
+func NewFileSnapshotStore(dir string) *FileSnapshotStore {
+	return &FileSnapshotStore{Dir: dir}
+}
+
+func (store *FileSnapshotStore) path(campaignID, snapshotID string) string {
+	return filepath.Join(store.Dir, filepath.Base(campaignID), filepath.Base(snapshotID)+".json")
+}
+
+func (store *FileSnapshotStore) Save(_ context.Context, snapshot *CampaignSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	path := store.path(snapshot.CampaignID, snapshot.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// write to a temporary file first so a crash never leaves a partial
+	// snapshot behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(os.Rename(tmp, path))
+}
+
+func (store *FileSnapshotStore) Load(_ context.Context, campaignID, snapshotID string) (*CampaignSnapshot, error) {
+	data, err := os.ReadFile(store.path(campaignID, snapshotID))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	snapshot := new(CampaignSnapshot)
+	return snapshot, errors.WithStack(json.Unmarshal(data, snapshot))
+}
+
+func (store *FileSnapshotStore) List(_ context.Context, campaignID string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(store.Dir, filepath.Base(campaignID)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, ".json") {
+			ids = append(ids, strings.TrimSuffix(name, ".json"))
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// SnapshotCampaign fetches the campaign's current content and settings and
+// saves them to the store.
+func (api *API) SnapshotCampaign(ctx context.Context, store SnapshotStore, id string) (*CampaignSnapshot, error) {
+	campaign, err := api.GetCampaign(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := api.GetCampaignContent(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	snapshot := &CampaignSnapshot{
+		ID:          now.Format(snapshotIDFormat),
+		CampaignID:  id,
+		TakenAt:     now,
+		Settings:    campaign.Settings,
+		PlainText:   content.PlainText,
+		Html:        content.Html,
+		ArchiveHtml: content.ArchiveHtml,
+	}
+
+	return snapshot, store.Save(ctx, snapshot)
+}
+
+// RestoreCampaignSnapshot writes the snapshot's content and settings back to
+// its campaign. The archive HTML is generated by Mailchimp and not restored.
+func (api *API) RestoreCampaignSnapshot(ctx context.Context, snapshot *CampaignSnapshot) (*CampaignResponse, error) {
+	campaign, err := api.GetCampaign(ctx, snapshot.CampaignID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &CampaignContentUpdateRequest{Html: snapshot.Html, PlainText: snapshot.PlainText}
+	if _, err := api.UpdateCampaignContent(ctx, snapshot.CampaignID, content); err != nil {
+		return nil, err
+	}
+
+	// the recipients and tracking are kept as they are now
+	body := &CampaignCreationRequest{
+		Type: campaign.Type,
+		Recipients: CampaignCreationRecipients{
+			ListId:         campaign.Recipients.ListId,
+			SegmentOptions: campaign.Recipients.SegmentOptions,
+		},
+		Settings: snapshot.Settings.CreationSettings(),
+		Tracking: campaign.Tracking,
+	}
+
+	return api.UpdateCampaign(ctx, snapshot.CampaignID, body)
+}
+
+func (campaign *CampaignResponse) Snapshot(ctx context.Context, store SnapshotStore) (*CampaignSnapshot, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.SnapshotCampaign(ctx, store, campaign.ID)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Diff
+// ------------------------------------------------------------------------------------------------
+
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// envelopeSettings are the settings recipients see before opening the email.
+// They are reported apart from the other settings.
+var envelopeSettings = map[string]bool{
+	"subject_line": true,
+	"preview_text": true,
+	"from_name":    true,
+	"reply_to":     true,
+	"to_name":      true,
+}
+
+type SettingChange struct {
+	Field string // JSON name of the setting, e.g. subject_line
+	Old   string
+	New   string
+}
+
+type DiffLine struct {
+	Op   string // one of the Diff* consts
+	Text string
+}
+
+type ContentDiff struct {
+	Lines   []DiffLine
+	Added   int
+	Removed int
+}
+
+// String renders the changed lines prefixed with + and -.
+func (diff *ContentDiff) String() string {
+	var b strings.Builder
+	for _, line := range diff.Lines {
+		switch line.Op {
+		case DiffInsert:
+			b.WriteString("+ " + line.Text + "\n")
+		case DiffDelete:
+			b.WriteString("- " + line.Text + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// CampaignDiff is the difference between two snapshots. Content diffs are nil
+// when the content did not change.
+type CampaignDiff struct {
+	Envelope    []SettingChange
+	Settings    []SettingChange
+	Html        *ContentDiff
+	PlainText   *ContentDiff
+	ArchiveHtml *ContentDiff
+}
+
+func (diff *CampaignDiff) Changed() bool {
+	return len(diff.Envelope) > 0 || len(diff.Settings) > 0 ||
+		diff.Html != nil || diff.PlainText != nil || diff.ArchiveHtml != nil
+}
+
+// DiffSnapshots compares two snapshots. HTML is split at tag boundaries so
+// changes to minified markup still show up per element.
+func DiffSnapshots(from, to *CampaignSnapshot) *CampaignDiff {
+	diff := new(CampaignDiff)
+
+	a, b := reflect.ValueOf(from.Settings), reflect.ValueOf(to.Settings)
+	for i := 0; i < a.NumField(); i++ {
+		field := strings.Split(a.Type().Field(i).Tag.Get("json"), ",")[0]
+		oldValue, newValue := fmtSetting(a.Field(i)), fmtSetting(b.Field(i))
+		if oldValue == newValue {
+			continue
+		}
+
+		change := SettingChange{Field: field, Old: oldValue, New: newValue}
+		if envelopeSettings[field] {
+			diff.Envelope = append(diff.Envelope, change)
+		} else {
+			diff.Settings = append(diff.Settings, change)
+		}
+	}
+
+	diff.Html = diffContent(htmlLines(from.Html), htmlLines(to.Html))
+	diff.PlainText = diffContent(strings.Split(from.PlainText, "\n"), strings.Split(to.PlainText, "\n"))
+	diff.ArchiveHtml = diffContent(htmlLines(from.ArchiveHtml), htmlLines(to.ArchiveHtml))
+
+	return diff
+}
+
+func fmtSetting(value reflect.Value) string {
+	return fmt.Sprint(value.Interface())
+}
+
+// htmlLines splits HTML into lines and breaks lines between adjacent tags.
+func htmlLines(html string) []string {
+	var lines []string
+	for _, line := range strings.Split(html, "\n") {
+		line = strings.TrimRight(line, "\r")
+		for {
+			index := strings.Index(line, "><")
+			if index < 0 {
+				break
+			}
+			lines = append(lines, line[:index+1])
+			line = line[index+1:]
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// diffContent computes a line diff from the longest common subsequence, or
+// returns nil when both sides are equal.
+func diffContent(a, b []string) *ContentDiff {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	if prefix == len(a) && prefix == len(b) {
+		return nil
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	diff := new(ContentDiff)
+	for _, line := range a[:prefix] {
+		diff.Lines = append(diff.Lines, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	diff.lines(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+
+	for _, line := range a[len(a)-suffix:] {
+		diff.Lines = append(diff.Lines, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	return diff
+}
+
+func (diff *ContentDiff) add(op, text string) {
+	diff.Lines = append(diff.Lines, DiffLine{Op: op, Text: text})
+	switch op {
+	case DiffInsert:
+		diff.Added++
+	case DiffDelete:
+		diff.Removed++
+	}
+}
+
+// lines diffs a and b with Hirschberg's algorithm, which finds the same
+// longest common subsequence as the full table in linear space: a is split
+// in half and b where the common subsequences of both halves add up to the
+// longest.
+func (diff *ContentDiff) lines(a, b []string) {
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			diff.add(DiffInsert, line)
+		}
+		return
+	case len(b) == 0:
+		for _, line := range a {
+			diff.add(DiffDelete, line)
+		}
+		return
+	case len(a) == 1:
+		for j, line := range b {
+			if line == a[0] {
+				for _, inserted := range b[:j] {
+					diff.add(DiffInsert, inserted)
+				}
+				diff.add(DiffEqual, line)
+				for _, inserted := range b[j+1:] {
+					diff.add(DiffInsert, inserted)
+				}
+				return
+			}
+		}
+		diff.add(DiffDelete, a[0])
+		for _, line := range b {
+			diff.add(DiffInsert, line)
+		}
+		return
+	}
+
+	mid := len(a) / 2
+	head := lcsHead(a[:mid], b)
+	tail := lcsTail(a[mid:], b)
+
+	split, best := 0, -1
+	for k := range head {
+		if head[k]+tail[k] > best {
+			split, best = k, head[k]+tail[k]
+		}
+	}
+
+	diff.lines(a[:mid], b[:split])
+	diff.lines(a[mid:], b[split:])
+}
+
+// lcsHead returns the common subsequence lengths of a and every prefix b[:j].
+func lcsHead(a, b []string) []int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for i := range a {
+		current[0] = 0
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i] == b[j-1]:
+				current[j] = previous[j-1] + 1
+			case previous[j] >= current[j-1]:
+				current[j] = previous[j]
+			default:
+				current[j] = current[j-1]
+			}
+		}
+		previous, current = current, previous
+	}
+
+	return previous
+}
+
+// lcsTail returns the common subsequence lengths of a and every suffix b[j:].
+func lcsTail(a, b []string) []int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for i := len(a) - 1; i >= 0; i-- {
+		current[len(b)] = 0
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				current[j] = previous[j+1] + 1
+			case previous[j] >= current[j+1]:
+				current[j] = previous[j]
+			default:
+				current[j] = current[j+1]
+			}
+		}
+		previous, current = current, previous
+	}
+
+	return previous
+}
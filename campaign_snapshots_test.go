@@ -0,0 +1,107 @@
+package gochimp3
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	from := &CampaignSnapshot{
+		Settings:  CampaignResponseSettings{SubjectLine: "Hello", FromName: "Shop", InlineCss: true},
+		Html:      "<p>Hi</p><p>Sale</p><p>Bye</p>",
+		PlainText: "Hi\nSale",
+	}
+	to := &CampaignSnapshot{
+		Settings:  CampaignResponseSettings{SubjectLine: "Hello again", FromName: "Shop", TemplateId: 7, InlineCss: true},
+		Html:      "<p>Hi</p><p>Big sale</p><p>Bye</p>",
+		PlainText: "Hi\nSale",
+	}
+
+	diff := DiffSnapshots(from, to)
+	assert.True(t, diff.Changed())
+	assert.Equal(t, []SettingChange{{Field: "subject_line", Old: "Hello", New: "Hello again"}}, diff.Envelope)
+	assert.Equal(t, []SettingChange{{Field: "template_id", Old: "0", New: "7"}}, diff.Settings)
+	assert.Nil(t, diff.PlainText)
+	assert.Equal(t, "- <p>Sale</p>\n+ <p>Big sale</p>\n", diff.Html.String())
+	assert.Equal(t, 1, diff.Html.Added)
+	assert.Equal(t, 1, diff.Html.Removed)
+}
+
+func TestFileSnapshotStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileSnapshotStore(t.TempDir())
+
+	for _, id := range []string{"20240102T000000.000000000Z", "20240101T000000.000000000Z"} {
+		fatalIf(t, store.Save(ctx, &CampaignSnapshot{ID: id, CampaignID: "c1", Html: id}))
+	}
+
+	ids, err := store.List(ctx, "c1")
+	fatalIf(t, err)
+	assert.Equal(t, []string{"20240101T000000.000000000Z", "20240102T000000.000000000Z"}, ids)
+
+	snapshot, err := store.Load(ctx, "c1", ids[1])
+	fatalIf(t, err)
+	assert.Equal(t, ids[1], snapshot.Html)
+}
+
+func TestDiffContentLCS(t *testing.T) {
+	lcsLength := func(a, b []string) int {
+		table := make([][]int, len(a)+1)
+		for i := range table {
+			table[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					table[i][j] = table[i+1][j+1] + 1
+				} else if table[i+1][j] > table[i][j+1] {
+					table[i][j] = table[i+1][j]
+				} else {
+					table[i][j] = table[i][j+1]
+				}
+			}
+		}
+		return table[0][0]
+	}
+
+	random := rand.New(rand.NewSource(1))
+	lines := func() []string {
+		values := make([]string, random.Intn(30))
+		for i := range values {
+			values[i] = string(rune('a' + random.Intn(4)))
+		}
+		return values
+	}
+
+	for n := 0; n < 200; n++ {
+		a, b := lines(), lines()
+		diff := diffContent(a, b)
+		if diff == nil {
+			assert.Equal(t, a, b)
+			continue
+		}
+
+		fromA, fromB := []string{}, []string{}
+		equal := 0
+		for _, line := range diff.Lines {
+			switch line.Op {
+			case DiffEqual:
+				fromA, fromB = append(fromA, line.Text), append(fromB, line.Text)
+				equal++
+			case DiffDelete:
+				fromA = append(fromA, line.Text)
+			case DiffInsert:
+				fromB = append(fromB, line.Text)
+			}
+		}
+
+		assert.Equal(t, a, fromA)
+		assert.Equal(t, b, fromB)
+		assert.Equal(t, lcsLength(a, b), equal)
+		assert.Equal(t, len(a)-equal, diff.Removed)
+		assert.Equal(t, len(b)-equal, diff.Added)
+	}
+}
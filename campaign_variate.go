@@ -0,0 +1,175 @@
+package gochimp3
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	minVariateTestSize     = 10
+	maxVariateTestSize     = 100
+	maxVariateCombinations = 8
+)
+
+type VariateSettings struct {
+	WinnerCriteria string `json:"winner_criteria"` // one of the VariateWinner* consts
+
+	// WaitTime is the number of minutes to wait before choosing the winner.
+	// Ignored for VariateWinnerManual.
+	WaitTime int `json:"wait_time,omitempty"`
+
+	// TestSize is the percentage of recipients the combinations are sent to.
+	TestSize int `json:"test_size,omitempty"`
+
+	SubjectLines     []string `json:"subject_lines,omitempty"`
+	SendTimes        []string `json:"send_times,omitempty"`
+	FromNames        []string `json:"from_names,omitempty"`
+	ReplyToAddresses []string `json:"reply_to_addresses,omitempty"`
+
+	// Contents are descriptions of the content variations, set through the
+	// campaign content endpoint.
+	Contents []string `json:"contents,omitempty"`
+}
+
+// CombinationCount returns the number of combinations Mailchimp will create, one
+// for every pairing of the tested values.
+func (settings *VariateSettings) CombinationCount() int {
+	combinations := 1
+	for _, values := range [][]string{
+		settings.SubjectLines,
+		settings.SendTimes,
+		settings.FromNames,
+		settings.ReplyToAddresses,
+		settings.Contents,
+	} {
+		if len(values) > 0 {
+			combinations *= len(values)
+		}
+	}
+
+	return combinations
+}
+
+func (settings *VariateSettings) Validate() error {
+	if settings == nil {
+		return nil
+	}
+
+	switch settings.WinnerCriteria {
+	case VariateWinnerOpens, VariateWinnerClicks, VariateWinnerTotalRevenue:
+		if settings.WaitTime <= 0 {
+			return errors.Errorf("variate winner criteria %q requires a wait time", settings.WinnerCriteria)
+		}
+	case VariateWinnerManual:
+	default:
+		return errors.Errorf("invalid variate winner criteria %q", settings.WinnerCriteria)
+	}
+
+	if settings.TestSize != 0 && (settings.TestSize < minVariateTestSize || settings.TestSize > maxVariateTestSize) {
+		return errors.Errorf("variate test size must be between %d and %d, got %d",
+			minVariateTestSize, maxVariateTestSize, settings.TestSize)
+	}
+
+	for _, sendTime := range settings.SendTimes {
+		if _, err := time.Parse(time.RFC3339, sendTime); err != nil {
+			return errors.Wrapf(err, "invalid variate send time %q", sendTime)
+		}
+	}
+
+	combinations := settings.CombinationCount()
+	if combinations < 2 {
+		return errors.New("variate settings need at least two values for one of the tested fields")
+	}
+	if combinations > maxVariateCombinations {
+		return errors.Errorf("variate settings produce %d combinations, at most %d are allowed",
+			combinations, maxVariateCombinations)
+	}
+
+	return nil
+}
+
+type VariateSettingsResponse struct {
+	VariateSettings
+
+	WinningCombinationID string               `json:"winning_combination_id"`
+	WinningCampaignID    string               `json:"winning_campaign_id"`
+	Combinations         []VariateCombination `json:"combinations"`
+}
+
+// VariateCombination is one tested pairing. The subject line, send time, from
+// name, reply-to and content fields are indexes into the matching
+// VariateSettings slices.
+type VariateCombination struct {
+	ID                 string `json:"id"`
+	SubjectLine        int    `json:"subject_line"`
+	SendTime           int    `json:"send_time"`
+	FromName           int    `json:"from_name"`
+	ReplyTo            int    `json:"reply_to"`
+	ContentDescription int    `json:"content_description"`
+	Recipients         int    `json:"recipients"`
+}
+
+// HasWinner reports whether a winning combination has been picked.
+func (settings *VariateSettingsResponse) HasWinner() bool {
+	return settings.WinningCombinationID != ""
+}
+
+// Winner returns the winning combination, or nil while the test is running.
+func (settings *VariateSettingsResponse) Winner() *VariateCombination {
+	for i := range settings.Combinations {
+		if settings.Combinations[i].ID == settings.WinningCombinationID {
+			return &settings.Combinations[i]
+		}
+	}
+
+	return nil
+}
+
+// WinningCampaign fetches the campaign that was sent to the remaining
+// recipients with the winning combination.
+func (campaign *CampaignResponse) WinningCampaign(ctx context.Context) (*CampaignResponse, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	if campaign.VariateSettings == nil || campaign.VariateSettings.WinningCampaignID == "" {
+		return nil, errors.Errorf("campaign %s has no winning campaign", campaign.ID)
+	}
+
+	return campaign.api.GetCampaign(ctx, campaign.VariateSettings.WinningCampaignID, nil)
+}
+
+// ResolvedCombination holds the actual values a combination was sent with.
+type ResolvedCombination struct {
+	ID                 string
+	SubjectLine        string
+	SendTime           string
+	FromName           string
+	ReplyTo            string
+	ContentDescription string
+	Recipients         int
+}
+
+// Resolve looks up the combination's indexes in the settings. Fields that
+// were not tested are left empty.
+func (combination *VariateCombination) Resolve(settings *VariateSettings) ResolvedCombination {
+	return ResolvedCombination{
+		ID:                 combination.ID,
+		SubjectLine:        variateValue(settings.SubjectLines, combination.SubjectLine),
+		SendTime:           variateValue(settings.SendTimes, combination.SendTime),
+		FromName:           variateValue(settings.FromNames, combination.FromName),
+		ReplyTo:            variateValue(settings.ReplyToAddresses, combination.ReplyTo),
+		ContentDescription: variateValue(settings.Contents, combination.ContentDescription),
+		Recipients:         combination.Recipients,
+	}
+}
+
+func variateValue(values []string, index int) string {
+	if index < 0 || index >= len(values) {
+		return ""
+	}
+
+	return values[index]
+}
@@ -0,0 +1,114 @@
+package gochimp3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariateSettingsCombinationCount(t *testing.T) {
+	tests := map[string]struct {
+		settings VariateSettings
+		expected int
+	}{
+		"nothing tested": {VariateSettings{}, 1},
+		"subject lines":  {VariateSettings{SubjectLines: []string{"a", "b", "c"}}, 3},
+		"pairings": {VariateSettings{
+			SubjectLines: []string{"a", "b"},
+			FromNames:    []string{"x", "y"},
+			Contents:     []string{"short", "long"},
+		}, 8},
+		"single values": {VariateSettings{SubjectLines: []string{"a"}, FromNames: []string{"x", "y"}}, 2},
+	}
+
+	for name, test := range tests {
+		assert.Equal(t, test.expected, test.settings.CombinationCount(), name)
+	}
+}
+
+func TestVariateSettingsValidate(t *testing.T) {
+	valid := func() VariateSettings {
+		return VariateSettings{
+			WinnerCriteria: VariateWinnerOpens,
+			WaitTime:       60,
+			TestSize:       20,
+			SubjectLines:   []string{"a", "b"},
+		}
+	}
+
+	tests := map[string]struct {
+		change func(*VariateSettings)
+		valid  bool
+	}{
+		"valid":               {func(s *VariateSettings) {}, true},
+		"manual without wait": {func(s *VariateSettings) { s.WinnerCriteria, s.WaitTime = VariateWinnerManual, 0 }, true},
+		"opens without wait":  {func(s *VariateSettings) { s.WaitTime = 0 }, false},
+		"unknown criteria":    {func(s *VariateSettings) { s.WinnerCriteria = "bounces" }, false},
+		"default test size":   {func(s *VariateSettings) { s.TestSize = 0 }, true},
+		"smallest test size":  {func(s *VariateSettings) { s.TestSize = 10 }, true},
+		"largest test size":   {func(s *VariateSettings) { s.TestSize = 100 }, true},
+		"test size too small": {func(s *VariateSettings) { s.TestSize = 9 }, false},
+		"test size too large": {func(s *VariateSettings) { s.TestSize = 101 }, false},
+		"bad send time":       {func(s *VariateSettings) { s.SendTimes = []string{"tomorrow", "later"} }, false},
+		"send times":          {func(s *VariateSettings) { s.SendTimes = []string{"2026-01-02T10:00:00Z"} }, true},
+		"one combination":     {func(s *VariateSettings) { s.SubjectLines = []string{"a"} }, false},
+		"eight combinations":  {func(s *VariateSettings) { s.FromNames = []string{"x", "y"}; s.Contents = []string{"1", "2"} }, true},
+		"more than eight": {func(s *VariateSettings) {
+			s.SubjectLines = []string{"a", "b", "c"}
+			s.FromNames = []string{"x", "y", "z"}
+		}, false},
+		"more than eight in one": {func(s *VariateSettings) { s.SubjectLines = make([]string, 9) }, false},
+	}
+
+	for name, test := range tests {
+		settings := valid()
+		test.change(&settings)
+		if test.valid {
+			assert.NoError(t, settings.Validate(), name)
+		} else {
+			assert.Error(t, settings.Validate(), name)
+		}
+	}
+
+	var settings *VariateSettings
+	assert.NoError(t, settings.Validate())
+}
+
+func TestVariateCombinationResolve(t *testing.T) {
+	settings := &VariateSettings{
+		SubjectLines: []string{"Hello", "Hi"},
+		FromNames:    []string{"News", "Team"},
+	}
+
+	tests := map[string]struct {
+		combination VariateCombination
+		expected    ResolvedCombination
+	}{
+		"first": {
+			VariateCombination{ID: "c1", Recipients: 10},
+			ResolvedCombination{ID: "c1", SubjectLine: "Hello", FromName: "News", Recipients: 10},
+		},
+		"second": {
+			VariateCombination{ID: "c4", SubjectLine: 1, FromName: 1, Recipients: 12},
+			ResolvedCombination{ID: "c4", SubjectLine: "Hi", FromName: "Team", Recipients: 12},
+		},
+		"out of range": {
+			VariateCombination{ID: "c9", SubjectLine: 2, FromName: -1, SendTime: 3},
+			ResolvedCombination{ID: "c9"},
+		},
+	}
+
+	for name, test := range tests {
+		assert.Equal(t, test.expected, test.combination.Resolve(settings), name)
+	}
+}
+
+func TestVariateSettingsWinner(t *testing.T) {
+	settings := &VariateSettingsResponse{Combinations: []VariateCombination{{ID: "a"}, {ID: "b"}}}
+	assert.False(t, settings.HasWinner())
+	assert.Nil(t, settings.Winner())
+
+	settings.WinningCombinationID = "b"
+	assert.True(t, settings.HasWinner())
+	assert.Equal(t, "b", settings.Winner().ID)
+}
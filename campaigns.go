@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/cockroachdb/errors"
 )
@@ -12,9 +13,17 @@ const (
 	campaignsPath       = "/campaigns"
 	singleCampaignPath  = campaignsPath + "/%s"
 	campaignContentPath = singleCampaignPath + "/content"
-
-	sendTestPath = singleCampaignPath + "/actions/test"
-	sendPath     = singleCampaignPath + "/actions/send"
+	sendChecklistPath   = singleCampaignPath + "/send-checklist"
+
+	sendTestPath     = singleCampaignPath + "/actions/test"
+	sendPath         = singleCampaignPath + "/actions/send"
+	schedulePath     = singleCampaignPath + "/actions/schedule"
+	unschedulePath   = singleCampaignPath + "/actions/unschedule"
+	pausePath        = singleCampaignPath + "/actions/pause"
+	resumePath       = singleCampaignPath + "/actions/resume"
+	cancelSendPath   = singleCampaignPath + "/actions/cancel-send"
+	replicatePath    = singleCampaignPath + "/actions/replicate"
+	createResendPath = singleCampaignPath + "/actions/create-resend"
 
 	CampaignTypeRegular   = "regular"
 	CampaignTypePlaintext = "plaintext"
@@ -25,12 +34,33 @@ const (
 	CampaignSendTypeHtml      = "html"
 	CampaignSendTypePlaintext = "plaintext"
 
-	ConditionMatchAny = "any"
-	ConditionMatchAll = "all"
-
-	ConditionTypeInterests = "Interests"
-
-	ConditionOpContains = "interestcontains"
+	CampaignStatusSave      = "save"
+	CampaignStatusPaused    = "paused"
+	CampaignStatusSchedule  = "schedule"
+	CampaignStatusSending   = "sending"
+	CampaignStatusSent      = "sent"
+	CampaignStatusCanceled  = "canceled"
+	CampaignStatusCanceling = "canceling"
+	CampaignStatusArchived  = "archived"
+
+	CampaignActionSend       = "send"
+	CampaignActionSchedule   = "schedule"
+	CampaignActionUnschedule = "unschedule"
+	CampaignActionPause      = "pause"
+	CampaignActionResume     = "resume"
+	CampaignActionCancel     = "cancel"
+	CampaignActionReplicate  = "replicate"
+	CampaignActionResend     = "resend"
+
+	ResendShortcutToNonOpeners     = "to_non_openers"
+	ResendShortcutToNewSubscribers = "to_new_subscribers"
+	ResendShortcutToNonClickers    = "to_non_clickers"
+	ResendShortcutToNonPurchasers  = "to_non_purchasers"
+
+	VariateWinnerOpens        = "opens"
+	VariateWinnerClicks       = "clicks"
+	VariateWinnerManual       = "manual"
+	VariateWinnerTotalRevenue = "total_revenue"
 )
 
 type CampaignQueryParams struct {
@@ -109,17 +139,23 @@ type CampaignCreationRequest struct {
 	Type       string                     `json:"type"` // must be one of the CAMPAIGN_TYPE_* consts
 	Recipients CampaignCreationRecipients `json:"recipients"`
 	Settings   CampaignCreationSettings   `json:"settings"`
-	// variate_settings not implemented
-	Tracking CampaignTracking `json:"tracking"`
-	// rss_opts not implemented
-	// social_card not implemented
+	Tracking   CampaignTracking           `json:"tracking"`
+
+	// VariateSettings is required for CampaignTypeVariate campaigns.
+	VariateSettings *VariateSettings `json:"variate_settings,omitempty"`
+
+	// RSSOptions is required for CampaignTypeRss campaigns.
+	RSSOptions *RSSOptions `json:"rss_opts,omitempty"`
+
+	SocialCard *SocialCard `json:"social_card,omitempty"`
 }
 
 type CampaignResponseRecipients struct {
-	ListId         string `json:"list_id"`
-	ListName       string `json:"list_name"`
-	SegmentText    string `json:"segment_text"`
-	RecipientCount int    `json:"recipient_count"`
+	ListId         string                         `json:"list_id"`
+	ListName       string                         `json:"list_name"`
+	SegmentText    string                         `json:"segment_text"`
+	RecipientCount int                            `json:"recipient_count"`
+	SegmentOptions CampaignCreationSegmentOptions `json:"segment_opts"`
 }
 
 type CampaignResponseSettings struct {
@@ -141,6 +177,27 @@ type CampaignResponseSettings struct {
 	DragAndDrop     bool   `json:"drag_and_drop"`
 }
 
+// CreationSettings returns the settings in the shape used to create or
+// update a campaign.
+func (settings CampaignResponseSettings) CreationSettings() CampaignCreationSettings {
+	return CampaignCreationSettings{
+		SubjectLine:     settings.SubjectLine,
+		PreviewText:     settings.PreviewText,
+		Title:           settings.Title,
+		FromName:        settings.FromName,
+		ReplyTo:         settings.ReplyTo,
+		UseConversation: settings.UseConversation,
+		ToName:          settings.ToName,
+		FolderId:        settings.FolderId,
+		Authenticate:    settings.Authenticate,
+		AutoFooter:      settings.AutoFooter,
+		InlineCss:       settings.InlineCss,
+		AutoTweet:       settings.AutoTweet,
+		FbComments:      settings.FbComments,
+		TemplateId:      settings.TemplateId,
+	}
+}
+
 type CampaignTracking struct {
 	Opens           bool   `json:"opens"`
 	HtmlClicks      bool   `json:"html_clicks"`
@@ -152,9 +209,9 @@ type CampaignTracking struct {
 }
 
 type CampaignEcommerce struct {
-	TotalOrders  int `json:"total_orders"`
-	TotalSpent   int `json:"total_spent"`
-	TotalRevenue int `json:"total_revenue"`
+	TotalOrders  int   `json:"total_orders"`
+	TotalSpent   Money `json:"total_spent"`
+	TotalRevenue Money `json:"total_revenue"`
 }
 
 type CampaignReportSummary struct {
@@ -190,6 +247,9 @@ type CampaignResponse struct {
 	Tracking          CampaignTracking           `json:"tracking"`
 	ReportSummary     CampaignReportSummary      `json:"report_summary"`
 	DeliveryStatus    CampaignDeliveryStatus     `json:"delivery_status"`
+	VariateSettings   *VariateSettingsResponse   `json:"variate_settings,omitempty"`
+	RSSOptions        *RSSOptionsResponse        `json:"rss_opts,omitempty"`
+	SocialCard        *SocialCard                `json:"social_card,omitempty"`
 
 	api *API
 }
@@ -210,8 +270,8 @@ func (api *API) GetCampaigns(ctx context.Context, params *CampaignQueryParams) (
 		return nil, err
 	}
 
-	for _, l := range response.Campaigns {
-		l.api = api
+	for i := range response.Campaigns {
+		response.Campaigns[i].api = api
 	}
 
 	return response, nil
@@ -227,12 +287,44 @@ func (api *API) GetCampaign(ctx context.Context, id string, params *BasicQueryPa
 }
 
 func (api *API) CreateCampaign(ctx context.Context, body *CampaignCreationRequest) (*CampaignResponse, error) {
+	if body == nil {
+		return nil, errors.New("no campaign provided")
+	}
+	if err := body.Recipients.SegmentOptions.Validate(); err != nil {
+		return nil, err
+	}
+	if body.Type == CampaignTypeVariate && body.VariateSettings == nil {
+		return nil, errors.New("variate campaigns require variate settings")
+	}
+	if body.Type == CampaignTypeRss && body.RSSOptions == nil {
+		return nil, errors.New("RSS campaigns require RSS options")
+	}
+	if err := body.VariateSettings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := body.RSSOptions.Validate(); err != nil {
+		return nil, err
+	}
+
 	response := new(CampaignResponse)
 	response.api = api
 	return response, api.Request(ctx, http.MethodPost, campaignsPath, nil, body, response)
 }
 
 func (api *API) UpdateCampaign(ctx context.Context, id string, body *CampaignCreationRequest) (*CampaignResponse, error) {
+	if body == nil {
+		return nil, errors.New("no campaign provided")
+	}
+	if err := body.Recipients.SegmentOptions.Validate(); err != nil {
+		return nil, err
+	}
+	if err := body.VariateSettings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := body.RSSOptions.Validate(); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf(singleCampaignPath, id)
 
 	response := new(CampaignResponse)
@@ -279,6 +371,309 @@ func (api *API) SendCampaign(ctx context.Context, id string, body *SendCampaignR
 	return true, nil
 }
 
+type CampaignBatchDelivery struct {
+	BatchDelay int `json:"batch_delay"` // minutes between batches
+	BatchCount int `json:"batch_count"`
+}
+
+type ScheduleCampaignRequest struct {
+	ScheduleTime  string                 `json:"schedule_time"` // UTC in ISO 8601, on the quarter hour
+	Timewarp      bool                   `json:"timewarp,omitempty"`
+	BatchDelivery *CampaignBatchDelivery `json:"batch_delivery,omitempty"`
+}
+
+// Validate checks the request against the rules the API enforces on
+// scheduling.
+func (req *ScheduleCampaignRequest) Validate() error {
+	if req.ScheduleTime == "" {
+		return errors.New("no schedule time provided")
+	}
+
+	scheduleTime, err := time.Parse(time.RFC3339, req.ScheduleTime)
+	if err != nil {
+		return errors.Wrap(err, "schedule time must be in ISO 8601 format")
+	}
+
+	if scheduleTime.Minute()%15 != 0 || scheduleTime.Second() != 0 {
+		return errors.Errorf("schedule time %s is not on the quarter hour", req.ScheduleTime)
+	}
+
+	if req.Timewarp && req.BatchDelivery != nil {
+		return errors.New("timewarp and batch delivery cannot be combined")
+	}
+
+	return nil
+}
+
+type ResendCampaignRequest struct {
+	ShortcutType string `json:"shortcut_type,omitempty"` // one of the ResendShortcut* consts
+}
+
+func (api *API) ScheduleCampaign(ctx context.Context, id string, body *ScheduleCampaignRequest) (bool, error) {
+	if body == nil {
+		return false, errors.New("no schedule provided")
+	}
+	if err := body.Validate(); err != nil {
+		return false, err
+	}
+
+	endpoint := fmt.Sprintf(schedulePath, id)
+	err := api.Request(ctx, http.MethodPost, endpoint, nil, body, nil)
+
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (api *API) UnscheduleCampaign(ctx context.Context, id string) (bool, error) {
+	endpoint := fmt.Sprintf(unschedulePath, id)
+	return api.RequestOk(ctx, http.MethodPost, endpoint)
+}
+
+// PauseCampaign pauses an RSS campaign.
+func (api *API) PauseCampaign(ctx context.Context, id string) (bool, error) {
+	endpoint := fmt.Sprintf(pausePath, id)
+	return api.RequestOk(ctx, http.MethodPost, endpoint)
+}
+
+// ResumeCampaign resumes a paused RSS campaign.
+func (api *API) ResumeCampaign(ctx context.Context, id string) (bool, error) {
+	endpoint := fmt.Sprintf(resumePath, id)
+	return api.RequestOk(ctx, http.MethodPost, endpoint)
+}
+
+// CancelCampaign cancels a campaign that is currently sending.
+func (api *API) CancelCampaign(ctx context.Context, id string) (bool, error) {
+	endpoint := fmt.Sprintf(cancelSendPath, id)
+	return api.RequestOk(ctx, http.MethodPost, endpoint)
+}
+
+// ReplicateCampaign creates a copy of the campaign as a new draft.
+func (api *API) ReplicateCampaign(ctx context.Context, id string) (*CampaignResponse, error) {
+	endpoint := fmt.Sprintf(replicatePath, id)
+	response := new(CampaignResponse)
+	response.api = api
+	return response, api.Request(ctx, http.MethodPost, endpoint, nil, nil, response)
+}
+
+// ResendCampaign creates a resend of a sent campaign, to non-openers unless
+// another shortcut type is given.
+func (api *API) ResendCampaign(ctx context.Context, id string, body *ResendCampaignRequest) (*CampaignResponse, error) {
+	if body == nil {
+		body = &ResendCampaignRequest{ShortcutType: ResendShortcutToNonOpeners}
+	}
+
+	endpoint := fmt.Sprintf(createResendPath, id)
+	response := new(CampaignResponse)
+	response.api = api
+	return response, api.Request(ctx, http.MethodPost, endpoint, nil, body, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Send Checklist
+// ------------------------------------------------------------------------------------------------
+
+const (
+	ChecklistItemSuccess = "success"
+	ChecklistItemWarning = "warning"
+	ChecklistItemError   = "error"
+)
+
+type SendChecklist struct {
+	IsReady bool                `json:"is_ready"`
+	Items   []SendChecklistItem `json:"items"`
+
+	withLinks
+}
+
+type SendChecklistItem struct {
+	Type    string `json:"type"` // one of the ChecklistItem* consts
+	ID      int    `json:"id"`
+	Heading string `json:"heading"`
+	Details string `json:"details"`
+}
+
+func (api *API) GetSendChecklist(ctx context.Context, id string, params *BasicQueryParams) (*SendChecklist, error) {
+	endpoint := fmt.Sprintf(sendChecklistPath, id)
+	response := new(SendChecklist)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (campaign *CampaignResponse) GetSendChecklist(ctx context.Context, params *BasicQueryParams) (*SendChecklist, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetSendChecklist(ctx, campaign.ID, params)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Campaign State Machine
+// ------------------------------------------------------------------------------------------------
+
+type campaignTransition struct {
+	from  []string
+	to    string   // status after the action, empty if unchanged
+	types []string // campaign types the action applies to, all when nil
+}
+
+// Paused RSS campaigns are restarted with resume, send and schedule only
+// apply to drafts.
+var campaignTransitions = map[string]campaignTransition{
+	CampaignActionSend: {
+		from: []string{CampaignStatusSave},
+		to:   CampaignStatusSending,
+	},
+	CampaignActionSchedule: {
+		from: []string{CampaignStatusSave},
+		to:   CampaignStatusSchedule,
+	},
+	CampaignActionUnschedule: {
+		from: []string{CampaignStatusSchedule},
+		to:   CampaignStatusSave,
+	},
+	CampaignActionPause: {
+		from:  []string{CampaignStatusSending, CampaignStatusSchedule},
+		to:    CampaignStatusPaused,
+		types: []string{CampaignTypeRss},
+	},
+	CampaignActionResume: {
+		from:  []string{CampaignStatusPaused},
+		to:    CampaignStatusSending,
+		types: []string{CampaignTypeRss},
+	},
+	CampaignActionCancel: {
+		from: []string{CampaignStatusSending},
+		to:   CampaignStatusCanceling,
+	},
+	CampaignActionReplicate: {
+		from: []string{
+			CampaignStatusSave, CampaignStatusPaused, CampaignStatusSchedule, CampaignStatusSending,
+			CampaignStatusSent, CampaignStatusCanceled, CampaignStatusCanceling, CampaignStatusArchived,
+		},
+	},
+	CampaignActionResend: {
+		from:  []string{CampaignStatusSent},
+		types: []string{CampaignTypeRegular, CampaignTypePlaintext},
+	},
+}
+
+// CampaignTransitionError is returned when an action is not allowed for the
+// campaign's current type or status.
+type CampaignTransitionError struct {
+	CampaignID string
+	Action     string
+	Type       string
+	Status     string
+}
+
+func (err *CampaignTransitionError) Error() string {
+	return fmt.Sprintf("cannot %s %s campaign %s with status %q", err.Action, err.Type, err.CampaignID, err.Status)
+}
+
+// CanPerform checks the action against the campaign's type and status without
+// calling the API.
+func (campaign *CampaignResponse) CanPerform(action string) error {
+	transition, ok := campaignTransitions[action]
+	if !ok {
+		return errors.Errorf("unknown campaign action %q", action)
+	}
+
+	if !containsString(transition.from, campaign.Status) ||
+		(transition.types != nil && !containsString(transition.types, campaign.Type)) {
+		return &CampaignTransitionError{
+			CampaignID: campaign.ID,
+			Action:     action,
+			Type:       campaign.Type,
+			Status:     campaign.Status,
+		}
+	}
+
+	return nil
+}
+
+// perform checks the transition, runs the request and moves the campaign to
+// its new status.
+func (campaign *CampaignResponse) perform(action string, request func() (bool, error)) (bool, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return false, err
+	}
+
+	if err := campaign.CanPerform(action); err != nil {
+		return false, err
+	}
+
+	ok, err := request()
+	if err != nil {
+		return false, err
+	}
+
+	if to := campaignTransitions[action].to; to != "" {
+		campaign.Status = to
+	}
+
+	return ok, nil
+}
+
+func (campaign *CampaignResponse) Send(ctx context.Context) (bool, error) {
+	return campaign.perform(CampaignActionSend, func() (bool, error) {
+		return campaign.api.SendCampaign(ctx, campaign.ID, &SendCampaignRequest{CampaignId: campaign.ID})
+	})
+}
+
+func (campaign *CampaignResponse) Schedule(ctx context.Context, body *ScheduleCampaignRequest) (bool, error) {
+	return campaign.perform(CampaignActionSchedule, func() (bool, error) {
+		return campaign.api.ScheduleCampaign(ctx, campaign.ID, body)
+	})
+}
+
+func (campaign *CampaignResponse) Unschedule(ctx context.Context) (bool, error) {
+	return campaign.perform(CampaignActionUnschedule, func() (bool, error) {
+		return campaign.api.UnscheduleCampaign(ctx, campaign.ID)
+	})
+}
+
+func (campaign *CampaignResponse) Pause(ctx context.Context) (bool, error) {
+	return campaign.perform(CampaignActionPause, func() (bool, error) {
+		return campaign.api.PauseCampaign(ctx, campaign.ID)
+	})
+}
+
+func (campaign *CampaignResponse) Resume(ctx context.Context) (bool, error) {
+	return campaign.perform(CampaignActionResume, func() (bool, error) {
+		return campaign.api.ResumeCampaign(ctx, campaign.ID)
+	})
+}
+
+func (campaign *CampaignResponse) Cancel(ctx context.Context) (bool, error) {
+	return campaign.perform(CampaignActionCancel, func() (bool, error) {
+		return campaign.api.CancelCampaign(ctx, campaign.ID)
+	})
+}
+
+func (campaign *CampaignResponse) Replicate(ctx context.Context) (*CampaignResponse, error) {
+	var response *CampaignResponse
+	_, err := campaign.perform(CampaignActionReplicate, func() (bool, error) {
+		var err error
+		response, err = campaign.api.ReplicateCampaign(ctx, campaign.ID)
+		return err == nil, err
+	})
+
+	return response, err
+}
+
+func (campaign *CampaignResponse) Resend(ctx context.Context, body *ResendCampaignRequest) (*CampaignResponse, error) {
+	var response *CampaignResponse
+	_, err := campaign.perform(CampaignActionResend, func() (bool, error) {
+		var err error
+		response, err = campaign.api.ResendCampaign(ctx, campaign.ID, body)
+		return err == nil, err
+	})
+
+	return response, err
+}
+
 // ------------------------------------------------------------------------------------------------
 // Campaign Content Updates
 // ------------------------------------------------------------------------------------------------
@@ -295,6 +690,12 @@ type CampaignContentUpdateRequest struct {
 	Template  *CampaignContentTemplateRequest `json:"template,omitempty"`
 }
 
+// CampaignTemplateContentRequest sets the content from a template alone,
+// without the html, plain_text and url of CampaignContentUpdateRequest.
+type CampaignTemplateContentRequest struct {
+	Template *CampaignContentTemplateRequest `json:"template"`
+}
+
 type CampaignContentResponse struct {
 	withLinks
 
@@ -308,10 +709,33 @@ func (api *API) GetCampaignContent(ctx context.Context, id string, params *Basic
 	endpoint := fmt.Sprintf(campaignContentPath, id)
 	response := new(CampaignContentResponse)
 	response.api = api
-	return response, api.Request(ctx, http.MethodGet, endpoint, nil, params, response)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
 }
 
 func (api *API) UpdateCampaignContent(ctx context.Context, id string, body *CampaignContentUpdateRequest) (*CampaignContentResponse, error) {
+	if api.LintContent {
+		if err := api.lintCampaignContent(ctx, id, body); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := fmt.Sprintf(campaignContentPath, id)
+	response := new(CampaignContentResponse)
+	response.api = api
+	return response, api.Request(ctx, http.MethodPut, endpoint, nil, body, response)
+}
+
+func (api *API) UpdateCampaignTemplateContent(ctx context.Context, id string, body *CampaignTemplateContentRequest) (*CampaignContentResponse, error) {
+	if body == nil {
+		return nil, errors.New("no content provided")
+	}
+
+	if api.LintContent {
+		if err := api.lintCampaignContent(ctx, id, &CampaignContentUpdateRequest{Template: body.Template}); err != nil {
+			return nil, err
+		}
+	}
+
 	endpoint := fmt.Sprintf(campaignContentPath, id)
 	response := new(CampaignContentResponse)
 	response.api = api
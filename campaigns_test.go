@@ -0,0 +1,60 @@
+package gochimp3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignTransitions(t *testing.T) {
+	tests := []struct {
+		action  string
+		typ     string
+		status  string
+		allowed bool
+	}{
+		{CampaignActionSend, CampaignTypeRegular, CampaignStatusSave, true},
+		{CampaignActionSend, CampaignTypeRegular, CampaignStatusPaused, false},
+		{CampaignActionSend, CampaignTypeRegular, CampaignStatusSent, false},
+		{CampaignActionSend, CampaignTypeRss, CampaignStatusPaused, false},
+		{CampaignActionSchedule, CampaignTypeRegular, CampaignStatusSave, true},
+		{CampaignActionSchedule, CampaignTypeRegular, CampaignStatusPaused, false},
+		{CampaignActionSchedule, CampaignTypeRegular, CampaignStatusSchedule, false},
+		{CampaignActionUnschedule, CampaignTypeRegular, CampaignStatusSchedule, true},
+		{CampaignActionUnschedule, CampaignTypeRegular, CampaignStatusSave, false},
+		{CampaignActionPause, CampaignTypeRss, CampaignStatusSending, true},
+		{CampaignActionPause, CampaignTypeRegular, CampaignStatusSending, false},
+		{CampaignActionResume, CampaignTypeRss, CampaignStatusPaused, true},
+		{CampaignActionResume, CampaignTypeRegular, CampaignStatusPaused, false},
+		{CampaignActionResume, CampaignTypeRss, CampaignStatusSave, false},
+		{CampaignActionCancel, CampaignTypeRegular, CampaignStatusSending, true},
+		{CampaignActionCancel, CampaignTypeRegular, CampaignStatusSent, false},
+		{CampaignActionReplicate, CampaignTypeVariate, CampaignStatusSent, true},
+		{CampaignActionReplicate, CampaignTypeRss, CampaignStatusPaused, true},
+		{CampaignActionResend, CampaignTypeRegular, CampaignStatusSent, true},
+		{CampaignActionResend, CampaignTypePlaintext, CampaignStatusSent, true},
+		{CampaignActionResend, CampaignTypeVariate, CampaignStatusSent, false},
+		{CampaignActionResend, CampaignTypeRegular, CampaignStatusSave, false},
+	}
+
+	for _, test := range tests {
+		campaign := &CampaignResponse{ID: "c1", Type: test.typ, Status: test.status}
+		err := campaign.CanPerform(test.action)
+		name := test.action + " " + test.typ + " " + test.status
+		if test.allowed {
+			assert.NoError(t, err, name)
+		} else {
+			assert.IsType(t, &CampaignTransitionError{}, err, name)
+		}
+	}
+
+	campaign := &CampaignResponse{ID: "c1", Type: CampaignTypeRegular, Status: CampaignStatusSave}
+	assert.Error(t, campaign.CanPerform("launch"))
+}
+
+func TestScheduleCampaignRequiresBody(t *testing.T) {
+	ok, err := testAPI().ScheduleCampaign(context.Background(), "c1", nil)
+	assert.False(t, ok)
+	assert.Error(t, err)
+}
@@ -0,0 +1,325 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	ClickMapRegionEdit  = "mc:edit"
+	ClickMapRegionBlock = "block"
+)
+
+// ClickMapLink is a tracked link in the campaign HTML. The click counts are
+// per URL, Occurrences tells how many links of the email share them.
+type ClickMapLink struct {
+	Index      int    // order of the link in the HTML, starting at 0
+	URL        string // href with entities decoded
+	Text       string // anchor text, or the alt text of a linked image
+	Region     string // mc:edit name or block ID the link is in
+	RegionType string // one of the ClickMapRegion* consts, empty outside regions
+	Start      int    // byte offset of the <a> tag
+	End        int    // byte offset after the </a> tag
+
+	Occurrences           int
+	TotalClicks           int
+	UniqueClicks          int
+	ClickPercentage       float64
+	UniqueClickPercentage float64
+	Matched               bool // found in the click details
+}
+
+// ClickMapRegion sums the clicks of the distinct URLs in a region.
+type ClickMapRegion struct {
+	Name         string
+	Type         string
+	Links        int
+	TotalClicks  int
+	UniqueClicks int
+}
+
+type ClickMap struct {
+	CampaignID string
+	Links      []ClickMapLink
+	Regions    []ClickMapRegion
+
+	// Unmatched are clicked URLs not found in the HTML, e.g. links of the
+	// plain text version
+	Unmatched []ClickDetail
+
+	html string
+}
+
+// FindTrackedLinks returns the http and https links of the HTML with their
+// position. Other links, like mailto: or anchors, are not tracked by
+// Mailchimp and skipped.
+func FindTrackedLinks(content string) []ClickMapLink {
+	type frame struct {
+		name       string
+		region     string
+		regionType string
+	}
+
+	var links []ClickMapLink
+	var stack []frame
+	var current *ClickMapLink
+	var text strings.Builder
+	var alt string
+
+	for _, token := range scanHTML(content) {
+		switch token.kind {
+		case htmlText:
+			if current != nil {
+				text.WriteString(content[token.start:token.end])
+			}
+
+		case htmlStartTag:
+			if token.name == "a" {
+				href := strings.TrimSpace(html.UnescapeString(token.attrs["href"]))
+				lower := strings.ToLower(href)
+				if strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") {
+					link := ClickMapLink{Index: len(links), URL: href, Start: token.start, End: token.end}
+					if len(stack) > 0 {
+						link.Region, link.RegionType = stack[len(stack)-1].region, stack[len(stack)-1].regionType
+					}
+					links = append(links, link)
+					current = &links[len(links)-1]
+					text.Reset()
+					alt = ""
+				}
+			}
+			if token.name == "img" && current != nil && alt == "" {
+				alt = token.attrs["alt"]
+			}
+
+			if token.selfClosing || htmlVoidElements[token.name] {
+				continue
+			}
+
+			f := frame{name: token.name}
+			if len(stack) > 0 {
+				f.region, f.regionType = stack[len(stack)-1].region, stack[len(stack)-1].regionType
+			}
+			if name, ok := token.attrs["mc:edit"]; ok {
+				f.region, f.regionType = name, ClickMapRegionEdit
+			} else if id, ok := token.attrs["data-block-id"]; ok && f.regionType != ClickMapRegionEdit {
+				f.region, f.regionType = id, ClickMapRegionBlock
+			}
+			stack = append(stack, f)
+
+		case htmlEndTag:
+			if token.name == "a" && current != nil {
+				current.End = token.end
+				current.Text = strings.Join(strings.Fields(html.UnescapeString(text.String())), " ")
+				if current.Text == "" {
+					current.Text = alt
+				}
+				current = nil
+			}
+
+			// close up to the matching element, ignoring stray end tags
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].name == token.name {
+					stack = stack[:i]
+					break
+				}
+			}
+		}
+	}
+
+	// a link left open runs to the end of the content
+	if current != nil {
+		current.End = len(content)
+		current.Text = strings.Join(strings.Fields(html.UnescapeString(text.String())), " ")
+		if current.Text == "" {
+			current.Text = alt
+		}
+	}
+
+	return links
+}
+
+// normalizeClickURL makes URLs from the HTML and the click details comparable.
+// Mailchimp may add Google Analytics parameters to tracked links, so utm_*
+// parameters are ignored along with trailing slashes.
+func normalizeClickURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return strings.TrimSpace(raw)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.Fragment = ""
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// BuildClickMap joins the tracked links of the HTML with click details.
+func BuildClickMap(content string, details []ClickDetail) *ClickMap {
+	clickMap := &ClickMap{Links: FindTrackedLinks(content), html: content}
+
+	byURL := make(map[string]*ClickDetail, len(details))
+	for i := range details {
+		if clickMap.CampaignID == "" {
+			clickMap.CampaignID = details[i].CampaignID
+		}
+		byURL[normalizeClickURL(details[i].URL)] = &details[i]
+	}
+
+	occurrences := make(map[string]int)
+	for _, link := range clickMap.Links {
+		occurrences[normalizeClickURL(link.URL)]++
+	}
+
+	matched := make(map[string]bool)
+	regions := make(map[string]*ClickMapRegion)
+	counted := make(map[string]bool)
+	var regionOrder []string
+	for i := range clickMap.Links {
+		link := &clickMap.Links[i]
+		key := normalizeClickURL(link.URL)
+		link.Occurrences = occurrences[key]
+
+		if detail := byURL[key]; detail != nil {
+			link.Matched = true
+			link.TotalClicks = detail.TotalClicks
+			link.UniqueClicks = detail.UniqueClicks
+			link.ClickPercentage = detail.ClickPercentage
+			link.UniqueClickPercentage = detail.UniqueClickPercentage
+			matched[key] = true
+		}
+
+		if link.RegionType == "" {
+			continue
+		}
+
+		regionKey := link.RegionType + "\x00" + link.Region
+		region := regions[regionKey]
+		if region == nil {
+			region = &ClickMapRegion{Name: link.Region, Type: link.RegionType}
+			regions[regionKey] = region
+			regionOrder = append(regionOrder, regionKey)
+		}
+		region.Links++
+
+		// a URL repeated within a region only counts once
+		if !counted[regionKey+"\x00"+key] {
+			counted[regionKey+"\x00"+key] = true
+			region.TotalClicks += link.TotalClicks
+			region.UniqueClicks += link.UniqueClicks
+		}
+	}
+
+	for _, key := range regionOrder {
+		clickMap.Regions = append(clickMap.Regions, *regions[key])
+	}
+
+	for key, detail := range byURL {
+		if !matched[key] {
+			clickMap.Unmatched = append(clickMap.Unmatched, *detail)
+		}
+	}
+	sort.Slice(clickMap.Unmatched, func(i, j int) bool {
+		a, b := clickMap.Unmatched[i], clickMap.Unmatched[j]
+		return a.TotalClicks > b.TotalClicks || (a.TotalClicks == b.TotalClicks && a.URL < b.URL)
+	})
+
+	return clickMap
+}
+
+const clickMapStyle = `<style type="text/css">
+.clickmap-badge{display:inline-block;margin-right:4px;padding:1px 5px;border-radius:8px;` +
+	`background:#241c15;color:#fff;font:bold 11px/1.4 sans-serif;vertical-align:middle}
+a[data-clickmap]{outline:2px solid rgba(220,40,40,.2);outline-offset:1px}
+a[data-clickmap-heat="1"]{outline-color:rgba(220,40,40,.4)}
+a[data-clickmap-heat="2"]{outline-color:rgba(220,40,40,.6)}
+a[data-clickmap-heat="3"]{outline-color:rgba(220,40,40,.8)}
+a[data-clickmap-heat="4"]{outline-color:rgba(220,40,40,1)}
+</style>`
+
+// Overlay returns the campaign HTML with a badge of the total clicks and the
+// click percentage in front of every tracked link. Links are outlined in red,
+// stronger for more clicks.
+func (clickMap *ClickMap) Overlay() string {
+	maxClicks := 0
+	for _, link := range clickMap.Links {
+		if link.TotalClicks > maxClicks {
+			maxClicks = link.TotalClicks
+		}
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, link := range clickMap.Links {
+		// five heat levels relative to the most clicked link
+		heat := 0
+		if maxClicks > 0 {
+			heat = link.TotalClicks * 4 / maxClicks
+		}
+
+		b.WriteString(clickMap.html[last:link.Start])
+		fmt.Fprintf(&b, `<span class="clickmap-badge" title="%s">%d · %.1f%%</span>`,
+			html.EscapeString(link.URL), link.TotalClicks, link.ClickPercentage*100)
+		fmt.Fprintf(&b, `<a data-clickmap="%d" data-clickmap-heat="%d"`, link.Index, heat)
+		b.WriteString(clickMap.html[link.Start+len("<a") : link.End])
+		last = link.End
+	}
+	b.WriteString(clickMap.html[last:])
+
+	overlay := b.String()
+	if index := strings.Index(strings.ToLower(overlay), "</head>"); index >= 0 {
+		return overlay[:index] + clickMapStyle + overlay[index:]
+	}
+
+	return clickMapStyle + overlay
+}
+
+// GetClickMap fetches the campaign's HTML and all its click details and joins
+// them with BuildClickMap.
+func (api *API) GetClickMap(ctx context.Context, campaignID string) (*ClickMap, error) {
+	content, err := api.GetCampaignContent(ctx, campaignID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var details []ClickDetail
+	params := &ExtendedQueryParams{Count: maxPageSize}
+	for {
+		page, err := api.GetClickDetails(ctx, campaignID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		details = append(details, page.URLsClicked...)
+		params.Offset += len(page.URLsClicked)
+		if len(page.URLsClicked) == 0 || params.Offset >= page.TotalItems {
+			break
+		}
+	}
+
+	clickMap := BuildClickMap(content.Html, details)
+	clickMap.CampaignID = campaignID
+	return clickMap, nil
+}
+
+func (campaign *CampaignResponse) GetClickMap(ctx context.Context) (*ClickMap, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetClickMap(ctx, campaign.ID)
+}
@@ -0,0 +1,68 @@
+package gochimp3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const clickMapHTML = `<html><head><title>x</title></head><body>
+<!-- <a href="https://commented.example">no</a> -->
+<div mc:edit="header"><a href='https://shop.example/?utm_source=mc'><img src="logo.png" alt="Logo"></a></div>
+<table><tr><td mc:edit=body>
+  <p>Read <A HREF="https://shop.example/sale/?a=1&amp;b=2" class=big>the <b>big</b>
+  sale</a> now or <a href="mailto:hi@shop.example">mail us</a>.</p>
+  <a href="https://shop.example/sale?b=2&a=1">Shop</a>
+</td></tr></table>
+<script>var s = "<a href='https://script.example'>";</script>
+<div data-block-id="7"><a href="https://social.example">Follow</a></div>
+<a href="*|UNSUB|*">Unsubscribe</a>
+</body></html>`
+
+func TestFindTrackedLinks(t *testing.T) {
+	links := FindTrackedLinks(clickMapHTML)
+	assert.Len(t, links, 4)
+
+	assert.Equal(t, "https://shop.example/?utm_source=mc", links[0].URL)
+	assert.Equal(t, "Logo", links[0].Text)
+	assert.Equal(t, "header", links[0].Region)
+	assert.Equal(t, ClickMapRegionEdit, links[0].RegionType)
+
+	assert.Equal(t, "https://shop.example/sale/?a=1&b=2", links[1].URL)
+	assert.Equal(t, "the big sale", links[1].Text)
+	assert.Equal(t, "body", links[1].Region)
+	assert.True(t, strings.HasPrefix(clickMapHTML[links[1].Start:], "<A HREF"))
+	assert.True(t, strings.HasSuffix(clickMapHTML[:links[1].End], "sale</a>"))
+
+	assert.Equal(t, 2, links[2].Index)
+	assert.Equal(t, "body", links[2].Region)
+
+	assert.Equal(t, "7", links[3].Region)
+	assert.Equal(t, ClickMapRegionBlock, links[3].RegionType)
+}
+
+func TestBuildClickMap(t *testing.T) {
+	details := []ClickDetail{
+		{URL: "https://shop.example/sale?a=1&b=2", TotalClicks: 30, UniqueClicks: 20, ClickPercentage: 0.6},
+		{URL: "https://shop.example/", TotalClicks: 10, UniqueClicks: 8, ClickPercentage: 0.2},
+		{URL: "https://plain-text.example", TotalClicks: 10},
+	}
+
+	clickMap := BuildClickMap(clickMapHTML, details)
+	assert.Equal(t, 30, clickMap.Links[1].TotalClicks)
+	assert.Equal(t, 2, clickMap.Links[1].Occurrences)
+	assert.Equal(t, 10, clickMap.Links[0].TotalClicks)
+	assert.False(t, clickMap.Links[3].Matched)
+
+	assert.Len(t, clickMap.Regions, 3)
+	assert.Equal(t, ClickMapRegion{Name: "body", Type: ClickMapRegionEdit, Links: 2, TotalClicks: 30, UniqueClicks: 20}, clickMap.Regions[1])
+
+	assert.Len(t, clickMap.Unmatched, 1)
+	assert.Equal(t, "https://plain-text.example", clickMap.Unmatched[0].URL)
+
+	overlay := clickMap.Overlay()
+	assert.Contains(t, overlay, `<span class="clickmap-badge" title="https://shop.example/sale/?a=1&amp;b=2">30 · 60.0%</span><a data-clickmap="1" data-clickmap-heat="4" HREF=`)
+	assert.Less(t, strings.Index(overlay, "<style"), strings.Index(overlay, "</head>"))
+	assert.Equal(t, 4, strings.Count(overlay, "clickmap-badge\""))
+}
@@ -5,6 +5,9 @@ import (
 	"strings"
 )
 
+// maxPageSize is the largest count the API accepts on paged endpoints
+const maxPageSize = 1000
+
 // APIError is what the api returns on error
 type APIError struct {
 	Type     string `json:"type,omitempty"`
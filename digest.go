@@ -0,0 +1,298 @@
+package gochimp3
+
+import (
+	"context"
+	htmltemplate "html/template"
+	"io"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	DigestFormatMarkdown = "markdown"
+	DigestFormatHTML     = "html"
+
+	digestPeriod = 7 * 24 * time.Hour
+	dayFormat    = "2006-01-02"
+)
+
+type DigestOptions struct {
+	// Since and Until bound the send time of the campaigns and the days of
+	// list activity. They default to the seven days up to now.
+	Since time.Time
+	Until time.Time
+
+	// ListIDs limits the list growth section, all lists are included when
+	// empty.
+	ListIDs []string
+}
+
+// DigestList is the growth of a list during the digest period.
+type DigestList struct {
+	ID          string
+	Name        string
+	MemberCount int
+	Subs        int
+	Unsubs      int
+	NetGrowth   int
+	Anomalies   []ListHealthAnomaly
+}
+
+// Digest holds everything rendered into a weekly report.
+type Digest struct {
+	Since       time.Time
+	Until       time.Time
+	Campaigns   *CampaignBenchmark
+	Automations []Automation
+	Lists       []DigestList
+}
+
+// GetDigest collects the campaigns sent in the period with their reports, the
+// active automations and the growth of the lists.
+func (api *API) GetDigest(ctx context.Context, opts *DigestOptions) (*Digest, error) {
+	if opts == nil {
+		opts = new(DigestOptions)
+	}
+
+	digest := &Digest{Since: opts.Since, Until: opts.Until}
+	if digest.Until.IsZero() {
+		digest.Until = time.Now()
+	}
+	if digest.Since.IsZero() {
+		digest.Since = digest.Until.Add(-digestPeriod)
+	}
+
+	var err error
+	digest.Campaigns, err = api.BenchmarkCampaigns(ctx, &CampaignQueryParams{
+		SinceSendTime:  digest.Since.UTC().Format(time.RFC3339),
+		BeforeSendTime: digest.Until.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "collecting campaigns")
+	}
+
+	automations, err := api.allAutomations(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "collecting automations")
+	}
+	for _, automation := range automations {
+		// drafts have never sent anything
+		if automation.Status != CampaignStatusSave {
+			digest.Automations = append(digest.Automations, automation)
+		}
+	}
+
+	lists, err := api.digestLists(ctx, opts.ListIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "collecting lists")
+	}
+
+	since, until := digest.Since.UTC().Format(dayFormat), digest.Until.UTC().Format(dayFormat)
+	for i := range lists {
+		list := &lists[i]
+		activity, err := list.recentActivity(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "growth of list %s", list.ID)
+		}
+
+		digest.Lists = append(digest.Lists, digestListGrowth(list, activity.Activities, since, until))
+	}
+
+	return digest, nil
+}
+
+// digestListGrowth sums the list activity of the days from since up to, but
+// not including, until.
+func digestListGrowth(list *ListResponse, activity []Activity, since, until string) DigestList {
+	health := AnalyzeListHealth(nil, activity, list.Stats, nil)
+
+	growth := DigestList{ID: list.ID, Name: list.Name, MemberCount: list.Stats.MemberCount}
+	for _, day := range health.Days {
+		if day.Day >= since && day.Day < until {
+			growth.Subs += day.Subs + day.OtherAdds
+			growth.Unsubs += day.Unsubs + day.OtherRemoves
+			growth.NetGrowth += day.NetGrowth
+		}
+	}
+	for _, anomaly := range health.Anomalies {
+		if anomaly.Period >= since && anomaly.Period < until {
+			growth.Anomalies = append(growth.Anomalies, anomaly)
+		}
+	}
+
+	return growth
+}
+
+func (api *API) digestLists(ctx context.Context, ids []string) ([]ListResponse, error) {
+	if len(ids) > 0 {
+		lists := make([]ListResponse, 0, len(ids))
+		for _, id := range ids {
+			list, err := api.GetList(ctx, id, nil)
+			if err != nil {
+				return nil, err
+			}
+			lists = append(lists, *list)
+		}
+		return lists, nil
+	}
+
+	var lists []ListResponse
+	params := new(ListQueryParams)
+	params.Count = maxPageSize
+	for {
+		page, err := api.GetLists(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		lists = append(lists, page.Lists...)
+		params.Offset += len(page.Lists)
+		if len(page.Lists) == 0 || params.Offset >= page.TotalItems {
+			return lists, nil
+		}
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+// Rendering
+// ------------------------------------------------------------------------------------------------
+
+// DefaultDigestMarkdown is the text/template used for Markdown digests.
+const DefaultDigestMarkdown = `# Mailchimp digest {{date .Since}} – {{date .Until}}
+
+## Campaigns
+{{with .Campaigns}}{{if .Rows}}
+| Campaign | Sent | Opens | Δ | Clicks | Δ | Bounces | Unsubscribes | Revenue / recipient |
+|---|---:|---:|---:|---:|---:|---:|---:|---:|
+{{range .Rows}}| {{md .Title}} | {{.EmailsSent}} | {{percent .OpenRate}} | {{delta .OpenRateDelta}} | {{percent .ClickRate}} | {{delta .ClickRateDelta}} | {{percent .BounceRate}} | {{percent .UnsubscribeRate}} | {{.RevenuePerRecipient}} {{.CurrencyCode}} |
+{{end}}{{with .Total}}| **Total** | {{.EmailsSent}} | {{percent .OpenRate}} | {{delta .OpenRateDelta}} | {{percent .ClickRate}} | {{delta .ClickRateDelta}} | {{percent .BounceRate}} | {{percent .UnsubscribeRate}} | {{.RevenuePerRecipient}} {{.CurrencyCode}} |{{end}}
+{{else}}
+No campaigns were sent.
+{{end}}{{end}}
+## Automations
+{{if .Automations}}
+| Automation | Status | Sent | Open rate | Click rate |
+|---|---|---:|---:|---:|
+{{range .Automations}}| {{md .Settings.Title}} | {{.Status}} | {{.EmailsSent}} | {{percent .ReportSummary.OpenRate}} | {{percent .ReportSummary.ClickRate}} |
+{{end}}{{else}}
+No active automations.
+{{end}}
+## Audience
+{{range .Lists}}
+### {{md .Name}}
+
+{{.MemberCount}} members, {{.Subs}} joined, {{.Unsubs}} left, net {{signed .NetGrowth}}.
+{{range .Anomalies}}
+- ⚠ {{.String}}{{end}}
+{{end}}`
+
+// DefaultDigestHTML is the html/template used for HTML digests.
+const DefaultDigestHTML = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Mailchimp digest {{date .Since}} – {{date .Until}}</title></head>
+<body>
+<h1>Mailchimp digest {{date .Since}} – {{date .Until}}</h1>
+<h2>Campaigns</h2>
+{{with .Campaigns}}{{if .Rows}}<table>
+<tr><th>Campaign</th><th>Sent</th><th>Opens</th><th>Δ</th><th>Clicks</th><th>Δ</th><th>Bounces</th><th>Unsubscribes</th><th>Revenue / recipient</th></tr>
+{{range .Rows}}<tr><td>{{.Title}}</td><td>{{.EmailsSent}}</td><td>{{percent .OpenRate}}</td><td>{{delta .OpenRateDelta}}</td><td>{{percent .ClickRate}}</td><td>{{delta .ClickRateDelta}}</td><td>{{percent .BounceRate}}</td><td>{{percent .UnsubscribeRate}}</td><td>{{.RevenuePerRecipient}} {{.CurrencyCode}}</td></tr>
+{{end}}{{with .Total}}<tr><th>Total</th><td>{{.EmailsSent}}</td><td>{{percent .OpenRate}}</td><td>{{delta .OpenRateDelta}}</td><td>{{percent .ClickRate}}</td><td>{{delta .ClickRateDelta}}</td><td>{{percent .BounceRate}}</td><td>{{percent .UnsubscribeRate}}</td><td>{{.RevenuePerRecipient}} {{.CurrencyCode}}</td></tr>{{end}}
+</table>{{else}}<p>No campaigns were sent.</p>{{end}}{{end}}
+<h2>Automations</h2>
+{{if .Automations}}<table>
+<tr><th>Automation</th><th>Status</th><th>Sent</th><th>Open rate</th><th>Click rate</th></tr>
+{{range .Automations}}<tr><td>{{.Settings.Title}}</td><td>{{.Status}}</td><td>{{.EmailsSent}}</td><td>{{percent .ReportSummary.OpenRate}}</td><td>{{percent .ReportSummary.ClickRate}}</td></tr>
+{{end}}</table>{{else}}<p>No active automations.</p>{{end}}
+<h2>Audience</h2>
+{{range .Lists}}<h3>{{.Name}}</h3>
+<p>{{.MemberCount}} members, {{.Subs}} joined, {{.Unsubs}} left, net {{signed .NetGrowth}}.</p>
+{{if .Anomalies}}<ul>{{range .Anomalies}}<li>⚠ {{.String}}</li>{{end}}</ul>
+{{end}}{{end}}</body></html>
+`
+
+// DigestRenderer renders digests with the default templates unless Markdown
+// or HTML are set. Funcs are added to the built-in template functions date,
+// percent, delta, signed and md.
+type DigestRenderer struct {
+	Markdown string
+	HTML     string
+	Funcs    map[string]any
+}
+
+func (renderer *DigestRenderer) funcs() map[string]any {
+	funcs := map[string]any{
+		"date": func(t time.Time) string { return t.Format(dayFormat) },
+		"percent": func(f float64) string {
+			return strconv.FormatFloat(f*100, 'f', 1, 64) + "%"
+		},
+		// deltas between rates are in percentage points
+		"delta": func(f float64) string {
+			s := strconv.FormatFloat(f*100, 'f', 1, 64) + " pp"
+			if f > 0 {
+				s = "+" + s
+			}
+			return s
+		},
+		"signed": func(n int) string {
+			if n > 0 {
+				return "+" + strconv.Itoa(n)
+			}
+			return strconv.Itoa(n)
+		},
+		// md escapes text for Markdown table cells and headings
+		"md": func(s string) string {
+			s = strings.Join(strings.Fields(s), " ")
+			return strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "<", "&lt;").Replace(s)
+		},
+	}
+	for name, fn := range renderer.Funcs {
+		funcs[name] = fn
+	}
+
+	return funcs
+}
+
+// Render writes the digest to w in one of the DigestFormat* consts.
+func (renderer *DigestRenderer) Render(w io.Writer, format string, digest *Digest) error {
+	switch format {
+	case "", DigestFormatMarkdown:
+		source := renderer.Markdown
+		if source == "" {
+			source = DefaultDigestMarkdown
+		}
+
+		tmpl, err := texttemplate.New("digest").Funcs(renderer.funcs()).Parse(source)
+		if err != nil {
+			return errors.Wrap(err, "parsing markdown digest template")
+		}
+		return errors.WithStack(tmpl.Execute(w, digest))
+
+	case DigestFormatHTML:
+		source := renderer.HTML
+		if source == "" {
+			source = DefaultDigestHTML
+		}
+
+		tmpl, err := htmltemplate.New("digest").Funcs(renderer.funcs()).Parse(source)
+		if err != nil {
+			return errors.Wrap(err, "parsing html digest template")
+		}
+		return errors.WithStack(tmpl.Execute(w, digest))
+
+	default:
+		return errors.Errorf("unknown digest format %q", format)
+	}
+}
+
+// WriteDigest collects a digest and renders it with the default templates.
+func (api *API) WriteDigest(ctx context.Context, w io.Writer, format string, opts *DigestOptions) error {
+	digest, err := api.GetDigest(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return new(DigestRenderer).Render(w, format, digest)
+}
@@ -0,0 +1,85 @@
+package gochimp3
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDigest() *Digest {
+	report := &CampaignReport{
+		ID:            "c1",
+		CampaignTitle: "Sale | <b>now</b>",
+		EmailsSent:    100,
+		Opens:         ReportOpens{UniqueOpens: 25},
+		Ecommerce:     ReportEcommerce{TotalRevenue: NewMoney(50, 0), CurrencyCode: "USD"},
+	}
+
+	digest := &Digest{
+		Since:     time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		Until:     time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		Campaigns: BenchmarkReports(IndustryStats{OpenRate: 0.2}, report),
+	}
+	digest.Automations = []Automation{{Status: "sending", EmailsSent: 40, Settings: AutomationSettingsShort{Title: "Welcome"}}}
+	digest.Lists = []DigestList{{
+		Name: "Newsletter", MemberCount: 1000, Subs: 12, Unsubs: 20, NetGrowth: -8,
+		Anomalies: []ListHealthAnomaly{{Period: "2024-03-06", Metric: ListHealthMetricUnsubscribes, Value: 15, Expected: 1, ZScore: 14, AfterSend: true}},
+	}}
+
+	return digest
+}
+
+func TestDigestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, new(DigestRenderer).Render(&buf, DigestFormatMarkdown, testDigest()))
+
+	out := buf.String()
+	assert.Contains(t, out, "# Mailchimp digest 2024-03-04 – 2024-03-11")
+	assert.Contains(t, out, `| Sale \| &lt;b>now&lt;/b> | 100 | 25.0% | +5.0 pp |`)
+	assert.Contains(t, out, "| 0.50 USD |")
+	assert.Contains(t, out, "| Welcome | sending | 40 |")
+	assert.Contains(t, out, "1000 members, 12 joined, 20 left, net -8.")
+	assert.Contains(t, out, "- ⚠ 2024-03-06: unsubscribes of 15, expected 1 (z=14.0) after a send")
+}
+
+func TestDigestRenderHTML(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, new(DigestRenderer).Render(&buf, DigestFormatHTML, testDigest()))
+
+	out := buf.String()
+	assert.Contains(t, out, "<td>Sale | &lt;b&gt;now&lt;/b&gt;</td>")
+	assert.Contains(t, out, "<li>⚠ 2024-03-06: unsubscribes")
+}
+
+func TestDigestRenderOverride(t *testing.T) {
+	renderer := &DigestRenderer{
+		Markdown: `{{shout (len .Lists)}}`,
+		Funcs:    map[string]any{"shout": func(n int) string { return "lists: " + string(rune('0'+n)) + "!" }},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, renderer.Render(&buf, DigestFormatMarkdown, testDigest()))
+	assert.Equal(t, "lists: 1!", buf.String())
+
+	assert.Error(t, renderer.Render(&buf, "pdf", testDigest()))
+}
+
+func TestDigestListGrowth(t *testing.T) {
+	var activity []Activity
+	for day := 1; day <= 11; day++ {
+		activity = append(activity, Activity{Day: fmt.Sprintf("2024-03-%02d", day), Subs: 3, Unsubs: 1})
+	}
+	activity[10].Unsubs = 50 // 2024-03-11, the day the digest ends
+
+	list := &ListResponse{ID: "list1", Stats: Stats{MemberCount: 1000}}
+	growth := digestListGrowth(list, activity, "2024-03-04", "2024-03-11")
+
+	assert.Equal(t, 1000, growth.MemberCount)
+	assert.Equal(t, 21, growth.Subs)
+	assert.Equal(t, 7, growth.Unsubs)
+	assert.Equal(t, 14, growth.NetGrowth)
+	assert.Empty(t, growth.Anomalies)
+}
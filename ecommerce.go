@@ -326,7 +326,7 @@ func (store *Store) DeleteCart(ctx context.Context, id string) (bool, error) {
 type OrderList struct {
 	APIError
 
-	Orders     []Order `json:"cart"`
+	Orders     []Order `json:"orders"`
 	TotalItems int     `json:"total_items"`
 	Links      []Link  `json:"_links,omitempty"`
 }
@@ -368,7 +368,7 @@ func (store *Store) GetOrders(ctx context.Context, params *ExtendedQueryParams)
 		return nil, errors.New("the store has an error, can't process request")
 	}
 
-	endpoint := fmt.Sprintf(cartsPath, store.ID)
+	endpoint := fmt.Sprintf(ordersPath, store.ID)
 	err := store.api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
 	if err != nil {
 		return nil, err
@@ -481,7 +481,7 @@ func (store *Store) GetProducts(ctx context.Context, params *ExtendedQueryParams
 		return nil, errors.New("the store has an error, can't process request")
 	}
 
-	endpoint := fmt.Sprintf(cartsPath, store.ID)
+	endpoint := fmt.Sprintf(productsPath, store.ID)
 	err := store.api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
 	if err != nil {
 		return nil, err
@@ -503,7 +503,7 @@ func (store *Store) GetProduct(ctx context.Context, id string, params *BasicQuer
 	res.api = store.api
 	res.StoreID = store.ID
 
-	endpoint := fmt.Sprintf(cartPath, store.ID, id)
+	endpoint := fmt.Sprintf(productPath, store.ID, id)
 	err := store.api.Request(ctx, http.MethodGet, endpoint, params, nil, res)
 	if err != nil {
 		return nil, err
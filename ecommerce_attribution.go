@@ -0,0 +1,213 @@
+package gochimp3
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// financial statuses of orders that don't count as revenue
+var nonRevenueStatuses = map[string]bool{
+	"cancelled": true,
+	"refunded":  true,
+}
+
+// RevenueAttribution is the order revenue of a store per campaign and per
+// automation email, sorted by revenue, highest first.
+type RevenueAttribution struct {
+	CurrencyCode     string
+	Campaigns        []CampaignRevenue
+	AutomationEmails []AutomationEmailRevenue
+
+	// Unattributed holds orders without a campaign ID
+	UnattributedOrders  int
+	UnattributedRevenue Money
+
+	// Skipped counts cancelled and refunded orders and orders in another
+	// currency, none of which are included above
+	Skipped int
+}
+
+type CampaignRevenue struct {
+	CampaignID  string
+	Title       string // empty when the campaign no longer exists
+	SubjectLine string
+	Orders      int
+	Revenue     Money
+}
+
+type AutomationEmailRevenue struct {
+	WorkflowID  string
+	EmailID     string
+	Title       string
+	SubjectLine string
+	Position    int
+	Orders      int
+	Revenue     Money
+}
+
+// Total returns the attributed revenue of campaigns and automation emails.
+func (attribution *RevenueAttribution) Total() Money {
+	var total Money
+	for _, campaign := range attribution.Campaigns {
+		total = total.Add(campaign.Revenue)
+	}
+	for _, email := range attribution.AutomationEmails {
+		total = total.Add(email.Revenue)
+	}
+
+	return total
+}
+
+// AttributeRevenue joins the orders' campaign IDs with campaigns and
+// automation emails, which share the ID space. Orders pointing at neither are
+// attributed to a campaign without title. An empty currency code takes the
+// currency of the first order that counts towards revenue.
+func AttributeRevenue(currencyCode string, orders []Order, campaigns []CampaignResponse, emails []AutomationEmail) *RevenueAttribution {
+	attribution := &RevenueAttribution{CurrencyCode: strings.ToUpper(currencyCode)}
+
+	campaignsByID := make(map[string]*CampaignResponse, len(campaigns))
+	for i := range campaigns {
+		campaignsByID[campaigns[i].ID] = &campaigns[i]
+	}
+	emailsByID := make(map[string]*AutomationEmail, len(emails))
+	for i := range emails {
+		emailsByID[emails[i].ID] = &emails[i]
+	}
+
+	campaignRevenue := make(map[string]*CampaignRevenue)
+	emailRevenue := make(map[string]*AutomationEmailRevenue)
+	for _, order := range orders {
+		if nonRevenueStatuses[strings.ToLower(order.FinancialStatus)] {
+			attribution.Skipped++
+			continue
+		}
+
+		currency := strings.ToUpper(order.CurrencyCode)
+		if attribution.CurrencyCode == "" {
+			attribution.CurrencyCode = currency
+		}
+		if currency != attribution.CurrencyCode {
+			attribution.Skipped++
+			continue
+		}
+
+		amount := MoneyFromFloat(order.OrderTotal)
+		switch email, isEmail := emailsByID[order.CampaignID]; {
+		case order.CampaignID == "":
+			attribution.UnattributedOrders++
+			attribution.UnattributedRevenue = attribution.UnattributedRevenue.Add(amount)
+
+		case isEmail:
+			revenue, ok := emailRevenue[email.ID]
+			if !ok {
+				revenue = &AutomationEmailRevenue{
+					WorkflowID:  email.WorkflowID,
+					EmailID:     email.ID,
+					Title:       email.Settings.Title,
+					SubjectLine: email.Settings.SubjectLine,
+					Position:    email.Position,
+				}
+				emailRevenue[email.ID] = revenue
+			}
+			revenue.Orders++
+			revenue.Revenue = revenue.Revenue.Add(amount)
+
+		default:
+			revenue, ok := campaignRevenue[order.CampaignID]
+			if !ok {
+				revenue = &CampaignRevenue{CampaignID: order.CampaignID}
+				if campaign := campaignsByID[order.CampaignID]; campaign != nil {
+					revenue.Title = campaign.Settings.Title
+					revenue.SubjectLine = campaign.Settings.SubjectLine
+				}
+				campaignRevenue[order.CampaignID] = revenue
+			}
+			revenue.Orders++
+			revenue.Revenue = revenue.Revenue.Add(amount)
+		}
+	}
+
+	for _, revenue := range campaignRevenue {
+		attribution.Campaigns = append(attribution.Campaigns, *revenue)
+	}
+	sort.Slice(attribution.Campaigns, func(i, j int) bool {
+		a, b := attribution.Campaigns[i], attribution.Campaigns[j]
+		return a.Revenue > b.Revenue || (a.Revenue == b.Revenue && a.CampaignID < b.CampaignID)
+	})
+
+	for _, revenue := range emailRevenue {
+		attribution.AutomationEmails = append(attribution.AutomationEmails, *revenue)
+	}
+	sort.Slice(attribution.AutomationEmails, func(i, j int) bool {
+		a, b := attribution.AutomationEmails[i], attribution.AutomationEmails[j]
+		return a.Revenue > b.Revenue || (a.Revenue == b.Revenue && a.EmailID < b.EmailID)
+	})
+
+	return attribution
+}
+
+// AttributeRevenue fetches all orders of the store and attributes their
+// revenue to the account's campaigns and automation emails.
+func (store *Store) AttributeRevenue(ctx context.Context) (*RevenueAttribution, error) {
+	if err := store.HasID(); err != nil {
+		return nil, err
+	}
+
+	var orders []Order
+	params := &ExtendedQueryParams{Count: maxPageSize}
+	for {
+		page, err := store.GetOrders(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, page.Orders...)
+		params.Offset += len(page.Orders)
+		if len(page.Orders) == 0 || params.Offset >= page.TotalItems {
+			break
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, order := range orders {
+		if order.CampaignID != "" {
+			ids[order.CampaignID] = true
+		}
+	}
+
+	automations, err := store.api.allAutomations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var emails []AutomationEmail
+	for _, automation := range automations {
+		list, err := store.api.GetAutomationEmails(ctx, automation.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "emails of automation %s", automation.ID)
+		}
+
+		for _, email := range list.Emails {
+			if ids[email.ID] {
+				emails = append(emails, email)
+				delete(ids, email.ID)
+			}
+		}
+	}
+
+	var campaigns []CampaignResponse
+	for id := range ids {
+		campaign, err := store.api.GetCampaign(ctx, id, nil)
+		if isNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "campaign %s", id)
+		}
+		campaigns = append(campaigns, *campaign)
+	}
+
+	return AttributeRevenue(store.CurrencyCode, orders, campaigns, emails), nil
+}
@@ -0,0 +1,72 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeRevenue(t *testing.T) {
+	orders := []Order{
+		{CampaignID: "c1", CurrencyCode: "usd", OrderTotal: 10.10},
+		{CampaignID: "c1", CurrencyCode: "USD", OrderTotal: 0.20},
+		{CampaignID: "e1", CurrencyCode: "USD", OrderTotal: 25},
+		{CampaignID: "gone", CurrencyCode: "USD", OrderTotal: 1},
+		{CurrencyCode: "USD", OrderTotal: 5},
+		{CampaignID: "c1", CurrencyCode: "USD", OrderTotal: 99, FinancialStatus: "refunded"},
+		{CampaignID: "c1", CurrencyCode: "EUR", OrderTotal: 99},
+	}
+	campaigns := []CampaignResponse{{ID: "c1", Settings: CampaignResponseSettings{Title: "Spring"}}}
+	emails := []AutomationEmail{{ID: "e1", WorkflowID: "w1", Position: 2}}
+
+	attribution := AttributeRevenue("USD", orders, campaigns, emails)
+	assert.Equal(t, 2, attribution.Skipped)
+	assert.Equal(t, 1, attribution.UnattributedOrders)
+	assert.Equal(t, NewMoney(5, 0), attribution.UnattributedRevenue)
+
+	assert.Len(t, attribution.Campaigns, 2)
+	assert.Equal(t, "c1", attribution.Campaigns[0].CampaignID)
+	assert.Equal(t, "Spring", attribution.Campaigns[0].Title)
+	assert.Equal(t, 2, attribution.Campaigns[0].Orders)
+	assert.Equal(t, "10.30", attribution.Campaigns[0].Revenue.String())
+	assert.Equal(t, "", attribution.Campaigns[1].Title)
+
+	assert.Len(t, attribution.AutomationEmails, 1)
+	assert.Equal(t, 2, attribution.AutomationEmails[0].Position)
+	assert.Equal(t, "36.30", attribution.Total().String())
+}
+
+func TestAttributeRevenueCurrencyFromRevenueOrders(t *testing.T) {
+	orders := []Order{
+		{CampaignID: "c1", CurrencyCode: "EUR", OrderTotal: 99, FinancialStatus: "cancelled"},
+		{CampaignID: "c1", CurrencyCode: "USD", OrderTotal: 10},
+		{CampaignID: "c1", CurrencyCode: "USD", OrderTotal: 5},
+	}
+
+	attribution := AttributeRevenue("", orders, nil, nil)
+	assert.Equal(t, "USD", attribution.CurrencyCode)
+	assert.Equal(t, 1, attribution.Skipped)
+	assert.Equal(t, "15.00", attribution.Total().String())
+}
+
+func TestAllAutomations(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/automations", r.URL.Path)
+		assert.Equal(t, "1000", r.URL.Query().Get("count"))
+		if r.URL.Query().Get("offset") == "0" {
+			_, _ = fmt.Fprint(w, `{"automations":[{"id":"a1"},{"id":"a2"}],"total_items":3}`)
+		} else {
+			assert.Equal(t, "2", r.URL.Query().Get("offset"))
+			_, _ = fmt.Fprint(w, `{"automations":[{"id":"a3"}],"total_items":3}`)
+		}
+	}
+
+	automations, err := testAPI().allAutomations(context.Background())
+	fatalIf(t, err)
+
+	assert.Len(t, automations, 3)
+	assert.Equal(t, "a3", automations[2].ID)
+}
@@ -0,0 +1,48 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreOrdersAndProducts(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case "/ecommerce/stores/s1/orders":
+			_, _ = fmt.Fprint(w, `{"store_id":"s1","orders":[{"id":"o1","currency_code":"USD","order_total":12.5,"campaign_id":"c1"}],"total_items":1}`)
+		case "/ecommerce/stores/s1/products":
+			_, _ = fmt.Fprint(w, `{"store_id":"s1","products":[{"id":"p1","title":"Mug"}],"total_items":1}`)
+		case "/ecommerce/stores/s1/products/p1":
+			_, _ = fmt.Fprint(w, `{"id":"p1","title":"Mug","variants":[{"id":"v1","title":"Blue","price":9.99}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+
+	store := &Store{ID: "s1", api: testAPI()}
+	ctx := context.Background()
+
+	orders, err := store.GetOrders(ctx, nil)
+	fatalIf(t, err)
+	if assert.Len(t, orders.Orders, 1) {
+		assert.Equal(t, "o1", orders.Orders[0].ID)
+		assert.Equal(t, 12.5, orders.Orders[0].OrderTotal)
+		assert.Equal(t, "c1", orders.Orders[0].CampaignID)
+	}
+
+	products, err := store.GetProducts(ctx, nil)
+	fatalIf(t, err)
+	if assert.Len(t, products.Products, 1) {
+		assert.Equal(t, "Mug", products.Products[0].Title)
+	}
+
+	product, err := store.GetProduct(ctx, "p1", nil)
+	fatalIf(t, err)
+	assert.Equal(t, "s1", product.StoreID)
+	assert.Equal(t, 9.99, product.Variants[0].Price)
+}
@@ -0,0 +1,311 @@
+package gochimp3
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+var emailActivityColumns = []string{
+	"campaign_id", "subscriber_hash", "email_address", "action", "type", "timestamp", "url", "ip",
+}
+
+// EmailActivityRow is a single open, click or bounce of one recipient.
+type EmailActivityRow struct {
+	CampaignID     string `json:"campaign_id"`
+	SubscriberHash string `json:"subscriber_hash"`
+	EmailAddress   string `json:"email_address"`
+	Action         string `json:"action"`
+	Type           string `json:"type,omitempty"`
+	Timestamp      string `json:"timestamp"`
+	URL            string `json:"url,omitempty"`
+	IP             string `json:"ip,omitempty"`
+}
+
+func (row *EmailActivityRow) record() []string {
+	return []string{
+		row.CampaignID, row.SubscriberHash, row.EmailAddress, row.Action,
+		row.Type, row.Timestamp, row.URL, row.IP,
+	}
+}
+
+// Rows flattens the recipient's activity, skipping events at or before since
+// when it is set.
+func (activity *EmailActivity) Rows(since time.Time) []EmailActivityRow {
+	hash := activity.EmailID
+	if hash == "" {
+		hash = SubscriberHash(activity.EmailAddress)
+	}
+
+	rows := make([]EmailActivityRow, 0, len(activity.Activity))
+	for _, event := range activity.Activity {
+		if !since.IsZero() {
+			if timestamp, err := time.Parse(time.RFC3339, event.Timestamp); err == nil && !timestamp.After(since) {
+				continue
+			}
+		}
+
+		rows = append(rows, EmailActivityRow{
+			CampaignID:     activity.CampaignID,
+			SubscriberHash: hash,
+			EmailAddress:   activity.EmailAddress,
+			Action:         event.Action,
+			Type:           event.Type,
+			Timestamp:      event.Timestamp,
+			URL:            event.URL,
+			IP:             event.IP,
+		})
+	}
+
+	return rows
+}
+
+// ------------------------------------------------------------------------------------------------
+// Iterator
+// ------------------------------------------------------------------------------------------------
+
+// EmailActivityIterator pages through a campaign's email activity and yields
+// one row per event. Call Next until it returns false and check Err
+// afterwards.
+type EmailActivityIterator struct {
+	api        *API
+	campaignID string
+	params     ReportActivityQueryParams
+	since      time.Time
+
+	rows       []EmailActivityRow
+	recipients []int // the recipient offset of each row
+	index      int
+	total      int
+	done       bool
+	err        error
+}
+
+// IterateEmailActivity iterates the campaign's email activity. Only Since,
+// Count, Offset and the field filters of params are used.
+func (api *API) IterateEmailActivity(campaignID string, params *ReportActivityQueryParams) *EmailActivityIterator {
+	it := &EmailActivityIterator{api: api, campaignID: campaignID}
+	if params != nil {
+		it.params = *params
+	}
+
+	if it.params.Count <= 0 || it.params.Count > maxPageSize {
+		it.params.Count = maxPageSize
+	}
+
+	if it.params.Since != "" {
+		since, err := time.Parse(time.RFC3339, it.params.Since)
+		if err != nil {
+			it.err = errors.Wrapf(err, "invalid since %q", it.params.Since)
+		}
+		it.since = since
+	}
+
+	return it
+}
+
+func (campaign *CampaignResponse) IterateEmailActivity(params *ReportActivityQueryParams) *EmailActivityIterator {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return &EmailActivityIterator{err: err}
+	}
+
+	return campaign.api.IterateEmailActivity(campaign.ID, params)
+}
+
+// Next advances to the next row, fetching pages of recipients as needed.
+func (it *EmailActivityIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= len(it.rows) {
+		if it.done {
+			return false
+		}
+
+		page, err := it.api.GetEmailActivity(ctx, it.campaignID, &it.params)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.rows, it.recipients = nil, nil
+		for i := range page.Emails {
+			rows := page.Emails[i].Rows(it.since)
+			for range rows {
+				it.recipients = append(it.recipients, it.params.Offset+i)
+			}
+			it.rows = append(it.rows, rows...)
+		}
+		it.index = 0
+		it.params.Offset += len(page.Emails)
+		it.total = page.TotalItems
+		if len(page.Emails) < it.params.Count || it.params.Offset >= it.total {
+			it.done = true
+		}
+	}
+
+	return true
+}
+
+// Row returns the current row.
+func (it *EmailActivityIterator) Row() *EmailActivityRow {
+	if it.index >= len(it.rows) {
+		return nil
+	}
+
+	return &it.rows[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *EmailActivityIterator) Err() error {
+	return it.err
+}
+
+// TotalRecipients returns the recipient total reported by the last page.
+func (it *EmailActivityIterator) TotalRecipients() int {
+	return it.total
+}
+
+// GetMemberEmailActivityRows returns the flattened activity of a single
+// recipient.
+func (api *API) GetMemberEmailActivityRows(ctx context.Context, campaignID, email string, params *ReportActivityQueryParams) ([]EmailActivityRow, error) {
+	activity, err := api.GetMemberEmailActivity(ctx, campaignID, email, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if activity.EmailAddress == "" {
+		activity.EmailAddress = email
+	}
+	if activity.CampaignID == "" {
+		activity.CampaignID = campaignID
+	}
+
+	var since time.Time
+	if params != nil && params.Since != "" {
+		if since, err = time.Parse(time.RFC3339, params.Since); err != nil {
+			return nil, errors.Wrapf(err, "invalid since %q", params.Since)
+		}
+	}
+
+	return activity.Rows(since), nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// Export
+// ------------------------------------------------------------------------------------------------
+
+type EmailActivityExportOptions struct {
+	// Format is one of the ExportFormat* consts, defaults to CSV.
+	Format string
+
+	// Since only exports activity after the given ISO 8601 time, use the
+	// timestamp of the last load for incremental exports.
+	Since string
+
+	// PageSize is the number of recipients fetched per request, at most 1000.
+	PageSize int
+
+	// OmitHeader skips the CSV header row.
+	OmitHeader bool
+
+	// Resume continues a failed export from the result it returned. Since
+	// must be the same as in the failed export.
+	Resume *EmailActivityExportResult
+}
+
+type EmailActivityExportResult struct {
+	Rows int
+
+	// LastTimestamp is the latest event timestamp exported, to be passed as
+	// Since on the next incremental export. It is only set once the activity
+	// of every recipient was written.
+	LastTimestamp string
+
+	// Offset is the number of recipients whose activity was written when the
+	// export failed part-way. Activity is grouped by recipient, not sorted by
+	// time, so a failed export can only be continued through Resume.
+	Offset int
+
+	latest string
+}
+
+// ExportEmailActivity writes the campaign's email activity to w as CSV or
+// NDJSON rows.
+func (api *API) ExportEmailActivity(ctx context.Context, w io.Writer, campaignID string, opts *EmailActivityExportOptions) (*EmailActivityExportResult, error) {
+	if opts == nil {
+		opts = new(EmailActivityExportOptions)
+	}
+
+	writer, err := newExportWriter(opts.Format, w)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.OmitHeader {
+		if err := writer.Header(emailActivityColumns); err != nil {
+			return nil, err
+		}
+	}
+
+	params := new(ReportActivityQueryParams)
+	params.Since = opts.Since
+	params.Count = opts.PageSize
+
+	result := new(EmailActivityExportResult)
+	if opts.Resume != nil {
+		params.Offset = opts.Resume.Offset
+		result.latest = opts.Resume.latest
+	}
+
+	// the rows written before an error are flushed, the export can then be
+	// resumed from the recipient offset
+	fail := func(err error, offset int) (*EmailActivityExportResult, error) {
+		result.Offset = offset
+		if flushErr := writer.Flush(); flushErr != nil {
+			err = errors.WithSecondaryError(err, flushErr)
+		}
+		return result, err
+	}
+
+	it := api.IterateEmailActivity(campaignID, params)
+	for it.Next(ctx) {
+		row := it.Row()
+		if err := writer.Write(row.record(), row); err != nil {
+			// earlier rows of the recipient may have been written
+			return fail(err, it.recipients[it.index])
+		}
+
+		result.Rows++
+		if laterTimestamp(row.Timestamp, result.latest) {
+			result.latest = row.Timestamp
+		}
+	}
+	if err := it.Err(); err != nil {
+		// every recipient before the failed page was written
+		return fail(err, it.params.Offset)
+	}
+
+	result.LastTimestamp = result.latest
+	return result, writer.Flush()
+}
+
+// laterTimestamp reports whether the RFC 3339 timestamp a is after b, falling
+// back to comparing the strings when either does not parse.
+func laterTimestamp(a, b string) bool {
+	if b == "" {
+		return a != ""
+	}
+
+	ta, errA := time.Parse(time.RFC3339, a)
+	tb, errB := time.Parse(time.RFC3339, b)
+	if errA != nil || errB != nil {
+		return a > b
+	}
+
+	return ta.After(tb)
+}
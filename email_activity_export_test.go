@@ -0,0 +1,124 @@
+package gochimp3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailActivityRows(t *testing.T) {
+	activity := &EmailActivity{
+		CampaignID:   "c1",
+		EmailAddress: "Jane@Example.com",
+		Activity: []EmailActivityEvent{
+			{Action: EmailActivityOpen, Timestamp: "2026-01-01T09:00:00+00:00"},
+			{Action: EmailActivityClick, Timestamp: "2026-01-01T10:00:00+00:00", URL: "https://example.com"},
+			{Action: EmailActivityBounce, Type: "soft", Timestamp: "2026-01-01T11:00:00+00:00"},
+		},
+	}
+
+	rows := activity.Rows(time.Time{})
+	if assert.Len(t, rows, 3) {
+		assert.Equal(t, EmailActivityRow{
+			CampaignID:     "c1",
+			SubscriberHash: SubscriberHash("jane@example.com"),
+			EmailAddress:   "Jane@Example.com",
+			Action:         EmailActivityClick,
+			Timestamp:      "2026-01-01T10:00:00+00:00",
+			URL:            "https://example.com",
+		}, rows[1])
+	}
+
+	// since is exclusive, the event at the boundary was already exported
+	since := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	rows = activity.Rows(since)
+	if assert.Len(t, rows, 1) {
+		assert.Equal(t, EmailActivityBounce, rows[0].Action)
+	}
+
+	activity.EmailID = "abc"
+	assert.Equal(t, "abc", activity.Rows(time.Time{})[0].SubscriberHash)
+}
+
+func TestLaterTimestamp(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		later bool
+	}{
+		{"2026-01-01T10:00:00+00:00", "", true},
+		{"", "", false},
+		{"2026-01-01T10:00:00+00:00", "2026-01-01T09:00:00+00:00", true},
+		{"2026-01-01T09:00:00+00:00", "2026-01-01T10:00:00+00:00", false},
+		{"2026-01-01T10:00:00+00:00", "2026-01-01T10:00:00+00:00", false},
+		// compared as times, not strings
+		{"2026-01-01T10:00:00+02:00", "2026-01-01T09:00:00+00:00", false},
+		{"b", "a", true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.later, laterTimestamp(test.a, test.b), test.a+" > "+test.b)
+	}
+}
+
+func TestExportEmailActivityResume(t *testing.T) {
+	failing := true
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reports/c1/email-activity", r.URL.Path)
+		assert.Equal(t, "2026-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"emails":[{"campaign_id":"c1","email_id":"h1","email_address":"a@example.com",`+
+				`"activity":[{"action":"open","timestamp":"2026-01-01T09:00:00+00:00"}]}],"total_items":2}`)
+		case "1":
+			if failing {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = fmt.Fprint(w, `{"type":"error","title":"Internal Server Error","status":500}`)
+				return
+			}
+			// earlier than the last row of the failed export
+			_, _ = fmt.Fprint(w, `{"emails":[{"campaign_id":"c1","email_id":"h2","email_address":"b@example.com",`+
+				`"activity":[{"action":"click","timestamp":"2026-01-01T08:00:00+00:00"}]}],"total_items":2}`)
+		default:
+			t.Errorf("unexpected offset %s", r.URL.Query().Get("offset"))
+		}
+	}
+
+	opts := &EmailActivityExportOptions{
+		Format:   ExportFormatCSV,
+		Since:    "2026-01-01T00:00:00Z",
+		PageSize: 1,
+	}
+
+	var buf bytes.Buffer
+	result, err := testAPI().ExportEmailActivity(context.Background(), &buf, "c1", opts)
+	assert.Error(t, err)
+	assert.Equal(t, 1, result.Rows)
+	assert.Equal(t, 1, result.Offset)
+	assert.Empty(t, result.LastTimestamp)
+	assert.Equal(t, "campaign_id,subscriber_hash,email_address,action,type,timestamp,url,ip\n"+
+		"c1,h1,a@example.com,open,,2026-01-01T09:00:00+00:00,,\n", buf.String())
+
+	failing = false
+	opts.OmitHeader = true
+	opts.Resume = result
+	result, err = testAPI().ExportEmailActivity(context.Background(), &buf, "c1", opts)
+	fatalIf(t, err)
+	assert.Equal(t, 1, result.Rows)
+	assert.Equal(t, "2026-01-01T09:00:00+00:00", result.LastTimestamp)
+	assert.Equal(t, "campaign_id,subscriber_hash,email_address,action,type,timestamp,url,ip\n"+
+		"c1,h1,a@example.com,open,,2026-01-01T09:00:00+00:00,,\n"+
+		"c1,h2,b@example.com,click,,2026-01-01T08:00:00+00:00,,\n", buf.String())
+}
+
+func TestCampaignIterateEmailActivityGuard(t *testing.T) {
+	campaign := &CampaignResponse{api: testAPI()}
+
+	it := campaign.IterateEmailActivity(nil)
+	assert.False(t, it.Next(context.Background()))
+	assert.Error(t, it.Err())
+}
@@ -0,0 +1,76 @@
+package gochimp3
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+
+	"github.com/cockroachdb/errors"
+	json "github.com/json-iterator/go"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// exportWriter writes rows either as CSV records or as newline delimited JSON
+// objects. Callers hand over both representations and the writer picks the
+// one matching its format.
+type exportWriter interface {
+	Header(columns []string) error
+	Write(record []string, object any) error
+	Flush() error
+}
+
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case "", ExportFormatCSV:
+		return &csvExportWriter{w: csv.NewWriter(w)}, nil
+	case ExportFormatNDJSON:
+		return &ndjsonExportWriter{w: bufio.NewWriter(w)}, nil
+	default:
+		return nil, errors.Errorf("unknown export format %q", format)
+	}
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvExportWriter) Header(columns []string) error {
+	return errors.WithStack(cw.w.Write(columns))
+}
+
+func (cw *csvExportWriter) Write(record []string, _ any) error {
+	return errors.WithStack(cw.w.Write(record))
+}
+
+func (cw *csvExportWriter) Flush() error {
+	cw.w.Flush()
+	return errors.WithStack(cw.w.Error())
+}
+
+type ndjsonExportWriter struct {
+	w *bufio.Writer
+}
+
+func (nw *ndjsonExportWriter) Header(_ []string) error {
+	return nil
+}
+
+func (nw *ndjsonExportWriter) Write(_ []string, object any) error {
+	// sorted keys keep the output of map objects stable between runs
+	data, err := json.ConfigCompatibleWithStandardLibrary.Marshal(object)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	data = append(data, '\n')
+	_, err = nw.w.Write(data)
+	return errors.WithStack(err)
+}
+
+func (nw *ndjsonExportWriter) Flush() error {
+	return errors.WithStack(nw.w.Flush())
+}
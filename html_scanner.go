@@ -0,0 +1,179 @@
+package gochimp3
+
+import (
+	"strings"
+)
+
+const (
+	htmlText = iota
+	htmlStartTag
+	htmlEndTag
+	htmlComment
+)
+
+// elements that never have an end tag
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlToken is a piece of HTML found by scanHTML. Start and End are the byte
+// offsets of the token in the scanned string.
+type htmlToken struct {
+	kind        int
+	name        string // lower case tag name
+	attrs       map[string]string
+	selfClosing bool
+	start       int
+	end         int
+}
+
+// scanHTML splits HTML into text, tags and comments. It is a forgiving
+// scanner for email markup rather than a full HTML parser: a stray "<" is
+// text, and the contents of script and style elements are one text token.
+func scanHTML(content string) []htmlToken {
+	var tokens []htmlToken
+	textStart := 0
+
+	flushText := func(end int) {
+		if end > textStart {
+			tokens = append(tokens, htmlToken{kind: htmlText, start: textStart, end: end})
+		}
+	}
+
+	i := 0
+	for i < len(content) {
+		if content[i] != '<' {
+			i++
+			continue
+		}
+
+		var token htmlToken
+		var ok bool
+		switch {
+		case strings.HasPrefix(content[i:], "<!--"):
+			end := strings.Index(content[i+4:], "-->")
+			if end < 0 {
+				token, ok = htmlToken{kind: htmlComment, start: i, end: len(content)}, true
+			} else {
+				token, ok = htmlToken{kind: htmlComment, start: i, end: i + 4 + end + 3}, true
+			}
+		case strings.HasPrefix(content[i:], "<!") || strings.HasPrefix(content[i:], "<?"):
+			end := strings.IndexByte(content[i:], '>')
+			if end >= 0 {
+				token, ok = htmlToken{kind: htmlComment, start: i, end: i + end + 1}, true
+			}
+		case strings.HasPrefix(content[i:], "</"):
+			token, ok = scanHTMLTag(content, i, i+2, htmlEndTag)
+		default:
+			token, ok = scanHTMLTag(content, i, i+1, htmlStartTag)
+		}
+
+		if !ok {
+			i++
+			continue
+		}
+
+		flushText(i)
+		tokens = append(tokens, token)
+		i = token.end
+		textStart = i
+
+		// raw text elements end at their end tag only
+		if token.kind == htmlStartTag && !token.selfClosing && (token.name == "script" || token.name == "style") {
+			end := strings.Index(strings.ToLower(content[i:]), "</"+token.name)
+			if end < 0 {
+				end = len(content) - i
+			}
+			i += end
+		}
+	}
+	flushText(len(content))
+
+	return tokens
+}
+
+// scanHTMLTag reads a tag whose name starts at offset from. It fails when no
+// tag name follows or the tag is never closed.
+func scanHTMLTag(content string, start, from, kind int) (htmlToken, bool) {
+	token := htmlToken{kind: kind, start: start}
+
+	i := from
+	for i < len(content) && isHTMLNameChar(content[i]) {
+		i++
+	}
+	if i == from || !isASCIILetter(content[from]) {
+		return token, false
+	}
+	token.name = strings.ToLower(content[from:i])
+
+	for i < len(content) {
+		switch c := content[i]; {
+		case c == '>':
+			token.end = i + 1
+			return token, true
+		case c == '/' && i+1 < len(content) && content[i+1] == '>':
+			token.selfClosing = true
+			token.end = i + 2
+			return token, true
+		case isHTMLSpace(c) || c == '/':
+			i++
+		default:
+			nameStart := i
+			for i < len(content) && !isHTMLSpace(content[i]) && content[i] != '=' && content[i] != '>' && content[i] != '/' {
+				i++
+			}
+			name := strings.ToLower(content[nameStart:i])
+			value := ""
+
+			for i < len(content) && isHTMLSpace(content[i]) {
+				i++
+			}
+			if i < len(content) && content[i] == '=' {
+				i++
+				for i < len(content) && isHTMLSpace(content[i]) {
+					i++
+				}
+				if i < len(content) && (content[i] == '"' || content[i] == '\'') {
+					quote := content[i]
+					end := strings.IndexByte(content[i+1:], quote)
+					if end < 0 {
+						return token, false
+					}
+					value = content[i+1 : i+1+end]
+					i += end + 2
+				} else {
+					valueStart := i
+					for i < len(content) && !isHTMLSpace(content[i]) && content[i] != '>' {
+						i++
+					}
+					value = content[valueStart:i]
+				}
+			}
+
+			if kind == htmlStartTag {
+				if token.attrs == nil {
+					token.attrs = make(map[string]string)
+				}
+				if _, exists := token.attrs[name]; !exists {
+					token.attrs[name] = value
+				}
+			}
+		}
+	}
+
+	return token, false
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHTMLNameChar(c byte) bool {
+	return isASCIILetter(c) || (c >= '0' && c <= '9') || c == '-' || c == ':'
+}
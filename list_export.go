@@ -0,0 +1,385 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ExportColumnEmail                = "email"
+	ExportColumnStatus               = "status"
+	ExportColumnMergeFields          = "merge_fields"
+	ExportColumnInterests            = "interests"
+	ExportColumnTags                 = "tags"
+	ExportColumnStats                = "stats"
+	ExportColumnLocation             = "location"
+	ExportColumnMarketingPermissions = "marketing_permissions"
+
+	defaultExportPageSize = 500
+)
+
+var allExportColumns = []string{
+	ExportColumnEmail,
+	ExportColumnStatus,
+	ExportColumnMergeFields,
+	ExportColumnInterests,
+	ExportColumnTags,
+	ExportColumnStats,
+	ExportColumnLocation,
+	ExportColumnMarketingPermissions,
+}
+
+// exportColumnFields maps every export column to the member fields it needs,
+// so only those are requested through the fields query parameter.
+var exportColumnFields = map[string][]string{
+	ExportColumnEmail:                {"members.email_address"},
+	ExportColumnStatus:               {"members.status"},
+	ExportColumnMergeFields:          {"members.merge_fields"},
+	ExportColumnInterests:            {"members.interests"},
+	ExportColumnTags:                 {"members.tags"},
+	ExportColumnStats:                {"members.stats"},
+	ExportColumnLocation:             {"members.location"},
+	ExportColumnMarketingPermissions: {"members.marketing_permissions"},
+}
+
+// addressMergeKeys is the order address merge field parts are flattened in.
+var addressMergeKeys = []string{"addr1", "addr2", "city", "state", "zip", "country"}
+
+type MemberExportOptions struct {
+	// Format is one of the ExportFormat* consts, defaults to CSV.
+	Format string
+
+	// Columns is a selection of the ExportColumn* consts, defaults to all.
+	Columns []string
+
+	// Status only exports members with the given status when set.
+	Status string
+
+	// PageSize is the number of members fetched per request, at most 1000.
+	PageSize int
+
+	// Offset is the member offset to start from. Pass the last checkpoint to
+	// resume an interrupted export.
+	Offset int
+
+	// OmitHeader skips the CSV header row, e.g. when appending to a partial
+	// export.
+	OmitHeader bool
+
+	// Checkpoint is called after every page has been written and flushed
+	// with the offset the export can be resumed from.
+	Checkpoint func(offset int) error
+}
+
+type MemberExportResult struct {
+	Exported   int
+	Offset     int
+	TotalItems int
+}
+
+type memberExporter struct {
+	columns     []string
+	mergeFields []MergeField
+	interests   map[string]string
+}
+
+// ExportMembers pages through the list members and writes them to w as CSV or
+// NDJSON. Merge fields are flattened into one column per field named after
+// the merge field, and interests are written as their group names.
+func (list *ListResponse) ExportMembers(ctx context.Context, w io.Writer, opts *MemberExportOptions) (*MemberExportResult, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = new(MemberExportOptions)
+	}
+
+	exporter := &memberExporter{columns: opts.Columns}
+	if len(exporter.columns) == 0 {
+		exporter.columns = allExportColumns
+	}
+
+	for _, column := range exporter.columns {
+		if _, ok := exportColumnFields[column]; !ok {
+			return nil, errors.Errorf("unknown export column %q", column)
+		}
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultExportPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	writer, err := newExportWriter(opts.Format, w)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := exporter.load(ctx, list); err != nil {
+		return nil, err
+	}
+
+	if !opts.OmitHeader {
+		if err := writer.Header(exporter.header()); err != nil {
+			return nil, err
+		}
+	}
+
+	params := new(InterestCategoriesQueryParams)
+	params.Status = opts.Status
+	params.Fields = exporter.fields()
+	params.Count = pageSize
+
+	result := &MemberExportResult{Offset: opts.Offset}
+	for {
+		params.Offset = result.Offset
+
+		page, err := list.GetMembers(ctx, params)
+		if err != nil {
+			return result, err
+		}
+
+		for i := range page.Members {
+			member := &page.Members[i]
+			if err := writer.Write(exporter.record(member), exporter.object(member)); err != nil {
+				return result, err
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			return result, err
+		}
+
+		result.Exported += len(page.Members)
+		result.Offset += len(page.Members)
+		result.TotalItems = page.TotalItems
+
+		if opts.Checkpoint != nil {
+			if err := opts.Checkpoint(result.Offset); err != nil {
+				return result, err
+			}
+		}
+
+		if len(page.Members) < pageSize || result.Offset >= page.TotalItems {
+			return result, nil
+		}
+	}
+}
+
+func (exp *memberExporter) has(column string) bool {
+	for _, c := range exp.columns {
+		if c == column {
+			return true
+		}
+	}
+
+	return false
+}
+
+// load fetches the merge field and interest names used to label the export.
+func (exp *memberExporter) load(ctx context.Context, list *ListResponse) error {
+	if exp.has(ExportColumnMergeFields) {
+		params := new(MergeFieldsParams)
+		params.Count = maxPageSize
+
+		fields, err := list.GetMergeFields(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		exp.mergeFields = fields.MergeFields
+		sort.SliceStable(exp.mergeFields, func(i, j int) bool {
+			return exp.mergeFields[i].DisplayOrder < exp.mergeFields[j].DisplayOrder
+		})
+	}
+
+	if exp.has(ExportColumnInterests) {
+		names, err := list.interestNames(ctx)
+		if err != nil {
+			return err
+		}
+		exp.interests = names
+	}
+
+	return nil
+}
+
+// interestNames maps every interest ID on the list to its group name.
+func (list *ListResponse) interestNames(ctx context.Context) (map[string]string, error) {
+	interests, err := list.GetMergeTagInterests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(interests))
+	for _, interest := range interests {
+		names[interest.ID] = interest.Name
+	}
+
+	return names, nil
+}
+
+func (exp *memberExporter) fields() []string {
+	fields := []string{"total_items"}
+	for _, column := range exp.columns {
+		fields = append(fields, exportColumnFields[column]...)
+	}
+
+	return fields
+}
+
+func (exp *memberExporter) header() []string {
+	var header []string
+	for _, column := range exp.columns {
+		switch column {
+		case ExportColumnEmail:
+			header = append(header, "email_address")
+		case ExportColumnMergeFields:
+			for _, field := range exp.mergeFields {
+				header = append(header, field.Name)
+			}
+		case ExportColumnStats:
+			header = append(header, "avg_open_rate", "avg_click_rate")
+		case ExportColumnLocation:
+			header = append(header, "latitude", "longitude", "country_code", "timezone")
+		default:
+			header = append(header, column)
+		}
+	}
+
+	return header
+}
+
+func (exp *memberExporter) record(member *Member) []string {
+	var record []string
+	for _, column := range exp.columns {
+		switch column {
+		case ExportColumnEmail:
+			record = append(record, member.EmailAddress)
+		case ExportColumnStatus:
+			record = append(record, member.Status)
+		case ExportColumnMergeFields:
+			for _, field := range exp.mergeFields {
+				record = append(record, formatMergeValue(member.MergeFields[field.Tag]))
+			}
+		case ExportColumnInterests:
+			record = append(record, strings.Join(exp.interestGroups(member), "; "))
+		case ExportColumnTags:
+			record = append(record, strings.Join(memberTagNames(member), "; "))
+		case ExportColumnStats:
+			record = append(record,
+				fmt.Sprint(member.Stats.AvgOpenRate),
+				fmt.Sprint(member.Stats.AvgClickRate),
+			)
+		case ExportColumnLocation:
+			if member.Location == nil {
+				record = append(record, "", "", "", "")
+				continue
+			}
+			record = append(record,
+				fmt.Sprint(member.Location.Latitude),
+				fmt.Sprint(member.Location.Longitude),
+				strings.ToUpper(member.Location.CountryCode),
+				member.Location.Timezone,
+			)
+		case ExportColumnMarketingPermissions:
+			var enabled []string
+			for _, permission := range member.MarketingPermissions {
+				if permission.Enabled {
+					enabled = append(enabled, permission.Text)
+				}
+			}
+			record = append(record, strings.Join(enabled, "; "))
+		}
+	}
+
+	return record
+}
+
+func (exp *memberExporter) object(member *Member) map[string]any {
+	object := make(map[string]any, len(exp.columns))
+	for _, column := range exp.columns {
+		switch column {
+		case ExportColumnEmail:
+			object["email_address"] = member.EmailAddress
+		case ExportColumnStatus:
+			object[column] = member.Status
+		case ExportColumnMergeFields:
+			fields := make(map[string]any, len(exp.mergeFields))
+			for _, field := range exp.mergeFields {
+				fields[field.Name] = member.MergeFields[field.Tag]
+			}
+			object[column] = fields
+		case ExportColumnInterests:
+			object[column] = exp.interestGroups(member)
+		case ExportColumnTags:
+			object[column] = memberTagNames(member)
+		case ExportColumnStats:
+			object[column] = member.Stats
+		case ExportColumnLocation:
+			object[column] = member.Location
+		case ExportColumnMarketingPermissions:
+			object[column] = member.MarketingPermissions
+		}
+	}
+
+	return object
+}
+
+func (exp *memberExporter) interestGroups(member *Member) []string {
+	groups := []string{}
+	for id, subscribed := range member.Interests {
+		if !subscribed {
+			continue
+		}
+
+		if name, ok := exp.interests[id]; ok {
+			groups = append(groups, name)
+		} else {
+			groups = append(groups, id)
+		}
+	}
+	sort.Strings(groups)
+
+	return groups
+}
+
+func memberTagNames(member *Member) []string {
+	names := make([]string, 0, len(member.Tags))
+	for _, tag := range member.Tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
+
+// formatMergeValue flattens a merge field value into a single cell. Address
+// merge fields are returned by the API as objects and are joined in postal
+// order.
+func formatMergeValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any:
+		var parts []string
+		for _, key := range addressMergeKeys {
+			if part := formatMergeValue(v[key]); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
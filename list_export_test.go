@@ -0,0 +1,85 @@
+package gochimp3
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testExporter() (*memberExporter, []Member) {
+	exporter := &memberExporter{
+		columns: allExportColumns,
+		mergeFields: []MergeField{
+			{Tag: "FNAME", Name: "First Name"},
+			{Tag: "ADDRESS", Name: "Address"},
+		},
+		interests: map[string]string{"i1": "Weekly", "i2": "Offers"},
+	}
+
+	members := []Member{
+		{MemberResponse: MemberResponse{
+			EmailAddress: "jane@example.com",
+			Status:       "subscribed",
+			MergeFields: map[string]any{
+				"FNAME":   "Jane",
+				"ADDRESS": map[string]any{"addr1": "1 Main St", "city": "Springfield", "zip": "12345", "country": "US"},
+			},
+			Interests: map[string]bool{"i1": true, "i2": true, "i3": true, "i4": false},
+			Location:  &MemberLocation{Latitude: 1.5, Longitude: -2, CountryCode: "us", Timezone: "America/New_York"},
+			Tags:      []MemberTag{{ID: 1, Name: "vip"}, {ID: 2, Name: "beta"}},
+			MarketingPermissions: MarketingPermissions{
+				{Text: "Email", Enabled: true},
+				{Text: "Direct mail", Enabled: false},
+			},
+		}, Stats: MemberStats{AvgOpenRate: 0.5, AvgClickRate: 0.25}},
+		{MemberResponse: MemberResponse{
+			EmailAddress: "joe@example.com",
+			Status:       "unsubscribed",
+		}},
+	}
+
+	return exporter, members
+}
+
+func writeTestExport(t *testing.T, format string) string {
+	exporter, members := testExporter()
+
+	var buf bytes.Buffer
+	writer, err := newExportWriter(format, &buf)
+	fatalIf(t, err)
+
+	fatalIf(t, writer.Header(exporter.header()))
+	for i := range members {
+		fatalIf(t, writer.Write(exporter.record(&members[i]), exporter.object(&members[i])))
+	}
+	fatalIf(t, writer.Flush())
+
+	return buf.String()
+}
+
+func TestMemberExportCSV(t *testing.T) {
+	expected := "" +
+		"email_address,status,First Name,Address,interests,tags,avg_open_rate,avg_click_rate,latitude,longitude,country_code,timezone,marketing_permissions\n" +
+		"jane@example.com,subscribed,Jane,\"1 Main St, Springfield, 12345, US\",Offers; Weekly; i3,vip; beta,0.5,0.25,1.5,-2,US,America/New_York,Email\n" +
+		"joe@example.com,unsubscribed,,,,,0,0,,,,,\n"
+
+	assert.Equal(t, expected, writeTestExport(t, ExportFormatCSV))
+}
+
+func TestMemberExportNDJSON(t *testing.T) {
+	expected := `{"email_address":"jane@example.com","interests":["Offers","Weekly","i3"],"location":{"latitude":1.5,"longitude":-2,"gmtoff":0,"dstoff":0,"timezone":"America/New_York","country_code":"US"},"marketing_permissions":[{"marketing_permission_id":"","text":"Email","enabled":true},{"marketing_permission_id":"","text":"Direct mail","enabled":false}],"merge_fields":{"Address":{"addr1":"1 Main St","city":"Springfield","country":"US","zip":"12345"},"First Name":"Jane"},"stats":{"avg_open_rate":0.5,"avg_click_rate":0.25},"status":"subscribed","tags":["vip","beta"]}
+{"email_address":"joe@example.com","interests":[],"location":null,"marketing_permissions":null,"merge_fields":{"Address":null,"First Name":null},"stats":{"avg_open_rate":0,"avg_click_rate":0},"status":"unsubscribed","tags":[]}
+`
+
+	assert.Equal(t, expected, writeTestExport(t, ExportFormatNDJSON))
+}
+
+func TestMemberExportColumns(t *testing.T) {
+	exporter := &memberExporter{columns: []string{ExportColumnEmail, ExportColumnTags}}
+	assert.Equal(t, []string{"email_address", "tags"}, exporter.header())
+	assert.Equal(t, []string{"total_items", "members.email_address", "members.tags"}, exporter.fields())
+
+	_, err := newExportWriter("xml", new(bytes.Buffer))
+	assert.Error(t, err)
+}
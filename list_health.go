@@ -0,0 +1,305 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	ListHealthMetricUnsubscribes = "unsubscribes"
+	ListHealthMetricHardBounces  = "hard_bounces"
+	ListHealthMetricNetGrowth    = "net_growth"
+	ListHealthMetricChurnRate    = "churn_rate"
+	ListHealthMetricCleanedRate  = "cleaned_rate"
+
+	defaultAnomalyThreshold = 3
+	minAnomalySamples       = 5
+	velocityWindow          = 7
+
+	// maxActivityDays is the most daily activity the API returns
+	maxActivityDays = 180
+)
+
+type ListHealthOptions struct {
+	// Threshold is the z-score from which a value counts as an anomaly,
+	// defaults to 3.
+	Threshold float64
+}
+
+// ListHealthMonth is derived from a month of growth history. Rates are
+// fractions of the subscribers at the start of the month, Velocity is the net
+// growth per day.
+type ListHealthMonth struct {
+	Month        string
+	Subscribed   int
+	Unsubscribed int
+	Cleaned      int
+	Pending      int
+	NetGrowth    int
+	GrowthRate   float64
+	ChurnRate    float64
+	CleanedRate  float64
+	Velocity     float64
+}
+
+// ListHealthDay is derived from a day of list activity. UnsubRate is a
+// fraction of the list's current members, Velocity is the net growth per day
+// averaged over the trailing week.
+type ListHealthDay struct {
+	Day          string
+	EmailsSent   int
+	Subs         int
+	Unsubs       int
+	OtherAdds    int
+	OtherRemoves int
+	HardBounces  int
+	NetGrowth    int
+	UnsubRate    float64
+	Velocity     float64
+}
+
+// ListHealthAnomaly is a day or month whose metric is more than the threshold
+// of standard deviations away from the other days or months.
+type ListHealthAnomaly struct {
+	Period    string // day or month
+	Metric    string // one of the ListHealthMetric* consts
+	Value     float64
+	Expected  float64
+	ZScore    float64
+	AfterSend bool // a campaign went out on the day or the day before
+}
+
+func (anomaly *ListHealthAnomaly) String() string {
+	message := fmt.Sprintf("%s: %s of %.4g, expected %.4g (z=%.1f)",
+		anomaly.Period, anomaly.Metric, anomaly.Value, anomaly.Expected, anomaly.ZScore)
+	if anomaly.AfterSend {
+		message += " after a send"
+	}
+	return message
+}
+
+type ListHealth struct {
+	ListID    string
+	Stats     Stats
+	Months    []ListHealthMonth
+	Days      []ListHealthDay
+	Anomalies []ListHealthAnomaly
+}
+
+// AnalyzeListHealth turns growth history and daily activity into time series,
+// oldest first, and flags anomalies in them.
+func AnalyzeListHealth(history []GrowthHistory, activity []Activity, stats Stats, opts *ListHealthOptions) *ListHealth {
+	threshold := float64(defaultAnomalyThreshold)
+	if opts != nil && opts.Threshold > 0 {
+		threshold = opts.Threshold
+	}
+
+	health := &ListHealth{Stats: stats}
+
+	history = append([]GrowthHistory(nil), history...)
+	sort.Slice(history, func(i, j int) bool { return history[i].Month < history[j].Month })
+	for i, month := range history {
+		if health.ListID == "" {
+			health.ListID = month.ListID
+		}
+
+		m := ListHealthMonth{
+			Month:        month.Month,
+			Subscribed:   month.Subscribed,
+			Unsubscribed: month.Unsubscribed,
+			Cleaned:      month.Cleaned,
+			Pending:      month.Pending,
+		}
+
+		// without the previous month the start is estimated from the changes
+		start := month.Subscribed + month.Unsubscribed + month.Cleaned
+		if i > 0 {
+			start = history[i-1].Subscribed
+			m.NetGrowth = month.Subscribed - start
+		}
+
+		m.GrowthRate = ratio(float64(m.NetGrowth), start)
+		m.ChurnRate = ratio(float64(month.Unsubscribed), start)
+		m.CleanedRate = ratio(float64(month.Cleaned), start)
+		m.Velocity = float64(m.NetGrowth) / float64(daysInMonth(month.Month))
+		health.Months = append(health.Months, m)
+	}
+
+	activity = append([]Activity(nil), activity...)
+	sort.Slice(activity, func(i, j int) bool { return activity[i].Day < activity[j].Day })
+	for i, day := range activity {
+		d := ListHealthDay{
+			Day:          day.Day,
+			EmailsSent:   day.EmailsSent,
+			Subs:         day.Subs,
+			Unsubs:       day.Unsubs,
+			OtherAdds:    day.OtherAdds,
+			OtherRemoves: day.OtherRemoves,
+			HardBounces:  day.HardBounce,
+			NetGrowth:    day.Subs + day.OtherAdds - day.Unsubs - day.OtherRemoves,
+			UnsubRate:    ratio(float64(day.Unsubs), stats.MemberCount),
+		}
+
+		window := d.NetGrowth
+		from := i - velocityWindow + 1
+		if from < 0 {
+			from = 0
+		}
+		for _, previous := range health.Days[from:] {
+			window += previous.NetGrowth
+		}
+		d.Velocity = float64(window) / float64(i-from+1)
+
+		health.Days = append(health.Days, d)
+	}
+
+	health.flagDays(threshold)
+	health.flagMonths(threshold)
+	sort.SliceStable(health.Anomalies, func(i, j int) bool {
+		return health.Anomalies[i].Period < health.Anomalies[j].Period
+	})
+
+	return health
+}
+
+func (health *ListHealth) flagDays(threshold float64) {
+	unsubs := make([]float64, len(health.Days))
+	bounces := make([]float64, len(health.Days))
+	growth := make([]float64, len(health.Days))
+	for i, day := range health.Days {
+		unsubs[i] = float64(day.Unsubs)
+		bounces[i] = float64(day.HardBounces)
+		growth[i] = float64(day.NetGrowth)
+	}
+
+	afterSend := func(i int) bool {
+		return health.Days[i].EmailsSent > 0 || (i > 0 && health.Days[i-1].EmailsSent > 0)
+	}
+
+	// counts have a standard deviation of at least one, so a single
+	// unsubscribe on a quiet list is no spike
+	for _, series := range []struct {
+		metric string
+		values []float64
+		sign   float64
+	}{
+		{ListHealthMetricUnsubscribes, unsubs, 1},
+		{ListHealthMetricHardBounces, bounces, 1},
+		{ListHealthMetricNetGrowth, growth, -1},
+	} {
+		for _, outlier := range outliers(series.values, 1, threshold, series.sign) {
+			health.Anomalies = append(health.Anomalies, ListHealthAnomaly{
+				Period:    health.Days[outlier.index].Day,
+				Metric:    series.metric,
+				Value:     series.values[outlier.index],
+				Expected:  outlier.mean,
+				ZScore:    outlier.z,
+				AfterSend: afterSend(outlier.index),
+			})
+		}
+	}
+}
+
+func (health *ListHealth) flagMonths(threshold float64) {
+	churn := make([]float64, len(health.Months))
+	cleaned := make([]float64, len(health.Months))
+	for i, month := range health.Months {
+		churn[i] = month.ChurnRate
+		cleaned[i] = month.CleanedRate
+	}
+
+	for _, series := range []struct {
+		metric string
+		values []float64
+	}{
+		{ListHealthMetricChurnRate, churn},
+		{ListHealthMetricCleanedRate, cleaned},
+	} {
+		// rates are floored at a tenth of a percent
+		for _, outlier := range outliers(series.values, 0.001, threshold, 1) {
+			health.Anomalies = append(health.Anomalies, ListHealthAnomaly{
+				Period:   health.Months[outlier.index].Month,
+				Metric:   series.metric,
+				Value:    series.values[outlier.index],
+				Expected: outlier.mean,
+				ZScore:   outlier.z,
+			})
+		}
+	}
+}
+
+type outlier struct {
+	index int
+	mean  float64
+	z     float64
+}
+
+// outliers scores every value against the mean and standard deviation of the
+// other values, so a spike does not hide itself by inflating the deviation.
+// Only deviations in the direction of sign are returned.
+func outliers(values []float64, minStdDev, threshold, sign float64) []outlier {
+	if len(values) < minAnomalySamples {
+		return nil
+	}
+
+	var sum, sumSquares float64
+	for _, v := range values {
+		sum += v
+		sumSquares += v * v
+	}
+
+	var result []outlier
+	n := float64(len(values) - 1)
+	for i, v := range values {
+		mean := (sum - v) / n
+		variance := (sumSquares-v*v)/n - mean*mean
+		stdDev := math.Max(math.Sqrt(math.Max(variance, 0)), minStdDev)
+
+		z := (v - mean) / stdDev
+		if z*sign >= threshold {
+			result = append(result, outlier{index: i, mean: mean, z: z})
+		}
+	}
+
+	return result
+}
+
+func daysInMonth(month string) int {
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return 30
+	}
+
+	return t.AddDate(0, 1, -1).Day()
+}
+
+// recentActivity fetches as many days of activity as the API keeps, the
+// default is only ten.
+func (list *ListResponse) recentActivity(ctx context.Context) (*ListOfActivity, error) {
+	return list.getActivity(ctx, &ExtendedQueryParams{Count: maxActivityDays})
+}
+
+// GetHealth fetches the list's growth history and recent daily activity and
+// analyzes them together with the list stats.
+func (list *ListResponse) GetHealth(ctx context.Context, opts *ListHealthOptions) (*ListHealth, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	history, err := list.GetGrowthHistory(ctx, &ExtendedQueryParams{Count: maxPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := list.recentActivity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := AnalyzeListHealth(history.History, activity.Activities, list.Stats, opts)
+	health.ListID = list.ID
+	return health, nil
+}
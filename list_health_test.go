@@ -0,0 +1,72 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeListHealth(t *testing.T) {
+	history := []GrowthHistory{
+		{Month: "2024-03", Subscribed: 1100, Unsubscribed: 10, Cleaned: 5},
+		{Month: "2024-02", Subscribed: 1000, Unsubscribed: 10, Cleaned: 10},
+	}
+
+	var activity []Activity
+	for day := 1; day <= 14; day++ {
+		a := Activity{Day: fmt.Sprintf("2024-03-%02d", day), Subs: 5, Unsubs: 1 + day%2}
+		if day == 9 {
+			a.EmailsSent = 1000
+		}
+		if day == 10 {
+			a.Unsubs = 20
+		}
+		activity = append(activity, a)
+	}
+
+	health := AnalyzeListHealth(history, activity, Stats{MemberCount: 1100}, nil)
+
+	assert.Len(t, health.Months, 2)
+	assert.Equal(t, "2024-02", health.Months[0].Month)
+	march := health.Months[1]
+	assert.Equal(t, 100, march.NetGrowth)
+	assert.InDelta(t, 0.1, march.GrowthRate, 1e-9)
+	assert.InDelta(t, 0.01, march.ChurnRate, 1e-9)
+	assert.InDelta(t, 100.0/31, march.Velocity, 1e-9)
+
+	assert.Len(t, health.Days, 14)
+	assert.Equal(t, 4, health.Days[1].NetGrowth)
+	assert.InDelta(t, 3.5, health.Days[1].Velocity, 1e-9)
+	assert.InDelta(t, 20.0/1100, health.Days[9].UnsubRate, 1e-9)
+
+	assert.Len(t, health.Anomalies, 2)
+	spike := health.Anomalies[0]
+	assert.Equal(t, "2024-03-10", spike.Period)
+	assert.True(t, spike.AfterSend)
+	assert.Greater(t, spike.ZScore, 3.0)
+	assert.Equal(t, []string{ListHealthMetricUnsubscribes, ListHealthMetricNetGrowth},
+		[]string{health.Anomalies[0].Metric, health.Anomalies[1].Metric})
+}
+
+func TestGetHealthRequestsAllActivity(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/lists/list1/activity":
+			assert.Equal(t, "180", r.URL.Query().Get("count"))
+			_, _ = fmt.Fprint(w, `{"activity":[{"day":"2024-03-01","unsubs":2}],"total_items":1}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"history":[],"total_items":0}`)
+		}
+	}
+
+	list := &ListResponse{ID: "list1", Stats: Stats{MemberCount: 100}, api: testAPI()}
+	health, err := list.GetHealth(context.Background(), nil)
+	fatalIf(t, err)
+
+	assert.Equal(t, "list1", health.ListID)
+	assert.Len(t, health.Days, 1)
+	assert.InDelta(t, 0.02, health.Days[0].UnsubRate, 1e-9)
+}
@@ -246,6 +246,10 @@ type Activity struct {
 }
 
 func (list *ListResponse) GetActivity(ctx context.Context, params *BasicQueryParams) (*ListOfActivity, error) {
+	return list.getActivity(ctx, params)
+}
+
+func (list *ListResponse) getActivity(ctx context.Context, params QueryParams) (*ListOfActivity, error) {
 	if err := list.CanMakeRequest(); err != nil {
 		return nil, err
 	}
@@ -304,6 +308,16 @@ type GrowthHistory struct {
 	Imports  int    `json:"imports"`
 	OptIns   int    `json:"optins"`
 
+	// Subscribed is the total at the end of the month, the other counts are
+	// the members that changed to the status during the month
+	Subscribed    int `json:"subscribed"`
+	Unsubscribed  int `json:"unsubscribed"`
+	Reconfirm     int `json:"reconfirm"`
+	Cleaned       int `json:"cleaned"`
+	Pending       int `json:"pending"`
+	Deleted       int `json:"deleted"`
+	Transactional int `json:"transactional"`
+
 	withLinks
 }
 
@@ -2,8 +2,10 @@ package gochimp3
 
 import (
 	"context"
+	"crypto/md5"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 )
@@ -45,6 +47,8 @@ type MemberResponse struct {
 	Tags            []MemberTag     `json:"tags,omitempty"`
 	TimestampSignup string          `json:"timestamp_signup,omitempty"`
 	TimestampOpt    string          `json:"timestamp_opt,omitempty"`
+
+	MarketingPermissions MarketingPermissions `json:"marketing_permissions,omitempty"`
 }
 
 type MemberRequest struct {
@@ -127,6 +131,12 @@ type MemberTag struct {
 	Name string `json:"name"`
 }
 
+// SubscriberHash returns the MD5 hash of the lowercase email address, which is
+// how the API identifies list members.
+func SubscriberHash(email string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.ToLower(email))))
+}
+
 func (list *ListResponse) GetMembers(ctx context.Context, params *InterestCategoriesQueryParams) (*ListOfMembers, error) {
 	if err := list.CanMakeRequest(); err != nil {
 		return nil, err
@@ -140,13 +150,28 @@ func (list *ListResponse) GetMembers(ctx context.Context, params *InterestCatego
 		return nil, err
 	}
 
-	for _, m := range response.Members {
-		m.api = list.api
+	for i := range response.Members {
+		response.Members[i].api = list.api
 	}
 
 	return response, nil
 }
 
+// IterateMembers returns an iterator over all list members matching params.
+// The params are copied, so the caller's Offset and Count are left alone.
+func (list *ListResponse) IterateMembers(params *InterestCategoriesQueryParams) *MemberIterator {
+	query := new(InterestCategoriesQueryParams)
+	if params != nil {
+		*query = *params
+	}
+
+	return newMemberIterator(query.Count, func(ctx context.Context, offset, count int) (*ListOfMembers, error) {
+		query.Offset = offset
+		query.Count = count
+		return list.GetMembers(ctx, query)
+	})
+}
+
 func (list *ListResponse) GetMember(ctx context.Context, id string, params *BasicQueryParams) (*Member, error) {
 	if err := list.CanMakeRequest(); err != nil {
 		return nil, err
@@ -213,6 +238,83 @@ func (list *ListResponse) DeleteMemberPermanent(ctx context.Context, id string)
 	return list.api.RequestOk(ctx, http.MethodPost, endpoint)
 }
 
+// ------------------------------------------------------------------------------------------------
+// Iterator
+// ------------------------------------------------------------------------------------------------
+
+// MemberIterator pages through members. Call Next until it returns false and
+// check Err afterwards.
+type MemberIterator struct {
+	fetch    func(ctx context.Context, offset, count int) (*ListOfMembers, error)
+	pageSize int
+
+	page   []Member
+	index  int
+	offset int
+	total  int
+	done   bool
+	err    error
+}
+
+func newMemberIterator(pageSize int, fetch func(ctx context.Context, offset, count int) (*ListOfMembers, error)) *MemberIterator {
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return &MemberIterator{fetch: fetch, pageSize: pageSize}
+}
+
+// Next advances to the next member, fetching the next page when needed.
+func (it *MemberIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	if it.index < len(it.page) {
+		return true
+	}
+
+	if it.done {
+		return false
+	}
+
+	page, err := it.fetch(ctx, it.offset, it.pageSize)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page = page.Members
+	it.index = 0
+	it.offset += len(page.Members)
+	it.total = page.TotalItems
+	if len(page.Members) < it.pageSize || it.offset >= it.total {
+		it.done = true
+	}
+
+	return len(it.page) > 0
+}
+
+// Member returns the current member.
+func (it *MemberIterator) Member() *Member {
+	if it.index >= len(it.page) {
+		return nil
+	}
+
+	return &it.page[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *MemberIterator) Err() error {
+	return it.err
+}
+
+// TotalItems returns the total reported by the last fetched page.
+func (it *MemberIterator) TotalItems() int {
+	return it.total
+}
+
 // ------------------------------------------------------------------------------------------------
 // Activity
 // ------------------------------------------------------------------------------------------------
@@ -0,0 +1,334 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxSuggestionDistance = 2
+
+// MergeTagLintIssue is a single problem found in content. Line and Column are
+// 1-based, Column counts characters.
+type MergeTagLintIssue struct {
+	Tag        string
+	Line       int
+	Column     int
+	Message    string
+	Suggestion string // a known tag the author probably meant, if any
+}
+
+func (issue MergeTagLintIssue) String() string {
+	message := fmt.Sprintf("%d:%d: %s", issue.Line, issue.Column, issue.Message)
+	if issue.Suggestion != "" {
+		message += fmt.Sprintf(" (did you mean %s?)", issue.Suggestion)
+	}
+
+	return message
+}
+
+// MergeTagLintError is returned by UpdateCampaignContent when API.LintContent
+// is enabled and the content has merge tag problems.
+type MergeTagLintError struct {
+	Issues []MergeTagLintIssue
+}
+
+func (err *MergeTagLintError) Error() string {
+	messages := make([]string, 0, len(err.Issues))
+	for _, issue := range err.Issues {
+		messages = append(messages, issue.String())
+	}
+
+	return "merge tag lint failed: " + strings.Join(messages, "; ")
+}
+
+type mergeTagLinter struct {
+	content   string
+	fields    map[string]bool // nil skips merge field checks
+	interests []MergeTagInterest
+	issues    []MergeTagLintIssue
+}
+
+// LintMergeTags checks every merge tag and conditional in content against the
+// list's merge fields, interest groups and Mailchimp's system tags, and
+// reports unbalanced conditional blocks.
+func LintMergeTags(content string, fields []MergeField, interests []MergeTagInterest) []MergeTagLintIssue {
+	tags := make([]string, 0, len(fields))
+	for _, field := range fields {
+		tags = append(tags, field.Tag)
+	}
+
+	return lintMergeTags(content, tags, interests)
+}
+
+func lintMergeTags(content string, fields []string, interests []MergeTagInterest) []MergeTagLintIssue {
+	linter := &mergeTagLinter{content: content, interests: interests}
+	if fields != nil {
+		linter.fields = make(map[string]bool, len(fields))
+		for _, tag := range fields {
+			linter.fields[strings.ToUpper(tag)] = true
+		}
+	}
+
+	tokens, malformed := tokenizeMergeTags(content)
+	for i := range malformed {
+		linter.report(&malformed[i], "*| without a closing |*", "")
+	}
+
+	_, problems := parseMergeTags(tokens)
+	for _, problem := range problems {
+		linter.report(problem.token, problem.message, "")
+	}
+
+	for i := range tokens {
+		if tokens[i].Tag {
+			linter.check(&tokens[i])
+		}
+	}
+
+	sort.SliceStable(linter.issues, func(i, j int) bool {
+		a, b := linter.issues[i], linter.issues[j]
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+
+	return linter.issues
+}
+
+func (linter *mergeTagLinter) report(token *mergeToken, message, suggestion string) {
+	line, column := lineColumn(linter.content, token.Start)
+	linter.issues = append(linter.issues, MergeTagLintIssue{
+		Tag:        token.Text,
+		Line:       line,
+		Column:     column,
+		Message:    message,
+		Suggestion: suggestion,
+	})
+}
+
+func (linter *mergeTagLinter) check(token *mergeToken) {
+	full := token.Name
+	if token.Arg != "" {
+		full += ":" + strings.ToUpper(token.Arg)
+	}
+	if systemMergeTags[full] || systemMergeTags[token.Name] {
+		return
+	}
+
+	switch token.Name {
+	case "ELSE", "END", "DATE":
+		return
+	case "IF", "IFNOT", "ELSEIF":
+		expr := token.Arg
+		for _, op := range mergeConditionOps {
+			if index := strings.Index(expr, op); index >= 0 {
+				expr = expr[:index]
+				break
+			}
+		}
+		if expr = strings.TrimSpace(expr); expr != "" {
+			linter.field(token, expr)
+		}
+	case "UPPER", "LOWER", "TITLE", "HTML", "URL":
+		linter.field(token, token.Arg)
+	case "INTERESTED":
+		linter.interested(token)
+	default:
+		linter.field(token, token.Name)
+	}
+}
+
+func (linter *mergeTagLinter) field(token *mergeToken, name string) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if linter.fields == nil || linter.fields[name] || systemMergeTags[name] {
+		return
+	}
+
+	candidates := make([]string, 0, len(linter.fields))
+	for tag := range linter.fields {
+		candidates = append(candidates, tag)
+	}
+
+	suggestion := closestMatch(name, candidates)
+	if suggestion != "" {
+		suggestion = "*|" + suggestion + "|*"
+	}
+	linter.report(token, fmt.Sprintf("unknown merge field %s", name), suggestion)
+}
+
+func (linter *mergeTagLinter) interested(token *mergeToken) {
+	if linter.interests == nil {
+		return
+	}
+
+	category, names, _ := strings.Cut(token.Arg, ":")
+	category = strings.TrimSpace(category)
+
+	var categories, known []string
+	for _, interest := range linter.interests {
+		if !containsString(categories, interest.Category) {
+			categories = append(categories, interest.Category)
+		}
+		if strings.EqualFold(interest.Category, category) {
+			known = append(known, interest.Name)
+		}
+	}
+
+	if len(known) == 0 {
+		linter.report(token, fmt.Sprintf("unknown interest category %q", category), closestMatch(category, categories))
+		return
+	}
+
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+
+		found := false
+		for _, interest := range known {
+			if strings.EqualFold(interest, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			linter.report(token, fmt.Sprintf("unknown interest %q in category %q", name, category), closestMatch(name, known))
+		}
+	}
+}
+
+// LintMergeTags lints content against the list's merge fields and interests.
+func (list *ListResponse) LintMergeTags(ctx context.Context, content ...string) ([]MergeTagLintIssue, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	params := new(MergeFieldsParams)
+	params.Count = maxPageSize
+
+	fields, err := list.GetMergeFields(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	interests, err := list.GetMergeTagInterests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if interests == nil {
+		interests = []MergeTagInterest{}
+	}
+
+	var issues []MergeTagLintIssue
+	for _, c := range content {
+		issues = append(issues, LintMergeTags(c, fields.MergeFields, interests)...)
+	}
+
+	return issues, nil
+}
+
+// LintCampaignContent lints the HTML, plain text and template sections of a
+// content update.
+func (list *ListResponse) LintCampaignContent(ctx context.Context, body *CampaignContentUpdateRequest) ([]MergeTagLintIssue, error) {
+	return list.LintMergeTags(ctx, campaignContentSections(body)...)
+}
+
+func campaignContentSections(body *CampaignContentUpdateRequest) []string {
+	content := []string{body.Html, body.PlainText}
+	if body.Template != nil {
+		for _, section := range body.Template.Sections {
+			content = append(content, section)
+		}
+	}
+
+	return content
+}
+
+func (list *ListResponse) LintTemplate(ctx context.Context, body *TemplateCreationRequest) ([]MergeTagLintIssue, error) {
+	return list.LintMergeTags(ctx, body.Html)
+}
+
+// lintCampaignContent looks up the campaign's list and lints the content
+// update against it.
+func (api *API) lintCampaignContent(ctx context.Context, id string, body *CampaignContentUpdateRequest) error {
+	campaign, err := api.GetCampaign(ctx, id, &BasicQueryParams{Fields: []string{"recipients.list_id"}})
+	if err != nil {
+		return err
+	}
+
+	var issues []MergeTagLintIssue
+	if campaign.Recipients.ListId == "" {
+		// without a list only the built-in tags can be checked
+		for _, content := range campaignContentSections(body) {
+			issues = append(issues, lintMergeTags(content, nil, nil)...)
+		}
+	} else {
+		list := &ListResponse{ID: campaign.Recipients.ListId, api: api}
+		issues, err = list.LintCampaignContent(ctx, body)
+		if err != nil {
+			return err
+		}
+	}
+	if len(issues) > 0 {
+		return &MergeTagLintError{Issues: issues}
+	}
+
+	return nil
+}
+
+func lineColumn(content string, offset int) (line, column int) {
+	line = 1 + strings.Count(content[:offset], "\n")
+	lineStart := strings.LastIndex(content[:offset], "\n") + 1
+	column = 1 + utf8.RuneCountInString(content[lineStart:offset])
+
+	return line, column
+}
+
+// closestMatch returns the candidate with the smallest edit distance to value
+// if it is close enough to be a likely typo.
+func closestMatch(value string, candidates []string) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, candidate := range candidates {
+		distance := levenshtein(strings.ToUpper(value), strings.ToUpper(candidate))
+		if distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,54 @@
+package gochimp3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintMergeTags(t *testing.T) {
+	fields := []MergeField{{Tag: "FNAME"}, {Tag: "LNAME"}}
+	interests := []MergeTagInterest{{ID: "i1", Category: "Topics", Name: "Go"}}
+
+	content := "*|ELSE:|*Hi *|FNMAE|*,\n*|IF:LNAME|*x*|INTERESTED:Topics:Go,Rsut|*y\n*|END:IF|* *|UNSUB|* *|"
+	issues := LintMergeTags(content, fields, interests)
+
+	var messages []string
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+
+	assert.Equal(t, []string{
+		`1:1: ELSE outside of an IF block`,
+		`1:13: unknown merge field FNMAE (did you mean *|FNAME|*?)`,
+		`2:1: *|IF:LNAME|* is never closed with END:IF`,
+		`2:14: *|INTERESTED:Topics:Go,Rsut|* is never closed with END:INTERESTED`,
+		`2:14: unknown interest "Rsut" in category "Topics"`,
+		`3:1: END:IF does not close *|INTERESTED:Topics:Go,Rsut|*`,
+		`3:22: *| without a closing |*`,
+	}, messages)
+}
+
+func TestLintCampaignContentWithoutList(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/campaigns/c1", r.URL.Path)
+		_, _ = fmt.Fprint(w, `{"id":"c1","recipients":{"list_id":""}}`)
+	}
+
+	api := testAPI()
+	ctx := context.Background()
+
+	// merge fields can't be checked without a list
+	err := api.lintCampaignContent(ctx, "c1", &CampaignContentUpdateRequest{Html: "Hi *|FNAME|* *|UNSUB|*"})
+	assert.NoError(t, err)
+
+	err = api.lintCampaignContent(ctx, "c1", &CampaignContentUpdateRequest{PlainText: "*|IF:FNAME|*Hi"})
+	var lintError *MergeTagLintError
+	if assert.True(t, errors.As(err, &lintError)) {
+		assert.Len(t, lintError.Issues, 1)
+	}
+}
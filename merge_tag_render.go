@@ -0,0 +1,476 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MergeTagInterest names an interest for INTERESTED blocks, which refer to
+// interests by category title and interest name rather than by ID.
+type MergeTagInterest struct {
+	ID       string
+	Category string
+	Name     string
+}
+
+// MergeTagRenderer expands merge tags locally the way Mailchimp would for a
+// single member, so content can be previewed without sending test emails.
+type MergeTagRenderer struct {
+	Member *Member
+
+	// Campaign fills campaign tags such as *|ARCHIVE|* and *|MC:SUBJECT|*
+	// when set.
+	Campaign *CampaignResponse
+
+	// Interests resolves INTERESTED blocks, see ListResponse.GetMergeTagInterests.
+	Interests []MergeTagInterest
+
+	// SystemTags overrides the value of system tags, e.g. "UNSUB".
+	SystemTags map[string]string
+
+	// Now is used for date tags, defaults to the current time.
+	Now time.Time
+}
+
+type MergeTagRenderResult struct {
+	Output string
+
+	// Unresolved holds the distinct tags that could not be expanded. They are
+	// left as is in the output.
+	Unresolved []string
+}
+
+func NewMergeTagRenderer(member *Member) *MergeTagRenderer {
+	return &MergeTagRenderer{Member: member}
+}
+
+// RenderHTML expands the merge tags in HTML content. Merge field values are
+// escaped unless the *|HTML:FIELD|* modifier is used.
+func (r *MergeTagRenderer) RenderHTML(content string) *MergeTagRenderResult {
+	return r.render(content, true)
+}
+
+// RenderText expands the merge tags in plain-text content.
+func (r *MergeTagRenderer) RenderText(content string) *MergeTagRenderResult {
+	return r.render(content, false)
+}
+
+// Preview renders both the HTML and the plain-text content for the member.
+func (content *CampaignContentResponse) Preview(r *MergeTagRenderer) (htmlResult, textResult *MergeTagRenderResult) {
+	return r.RenderHTML(content.Html), r.RenderText(content.PlainText)
+}
+
+// GetMergeTagInterests returns all interests on the list together with their
+// category titles.
+func (list *ListResponse) GetMergeTagInterests(ctx context.Context) ([]MergeTagInterest, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	categoryParams := new(InterestCategoriesQueryParams)
+	categoryParams.Count = maxPageSize
+
+	categories, err := list.GetInterestCategories(ctx, categoryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var interests []MergeTagInterest
+	for _, category := range categories.Categories {
+		params := new(ExtendedQueryParams)
+		params.Count = maxPageSize
+
+		response, err := list.GetInterests(ctx, category.ID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, interest := range response.Interests {
+			interests = append(interests, MergeTagInterest{
+				ID:       interest.ID,
+				Category: category.Title,
+				Name:     interest.Name,
+			})
+		}
+	}
+
+	return interests, nil
+}
+
+type mergeRender struct {
+	*MergeTagRenderer
+
+	html       bool
+	now        time.Time
+	out        strings.Builder
+	unresolved []string
+	seen       map[string]bool
+}
+
+func (r *MergeTagRenderer) render(content string, isHTML bool) *MergeTagRenderResult {
+	tokens, _ := tokenizeMergeTags(content)
+	nodes, problems := parseMergeTags(tokens)
+
+	state := &mergeRender{MergeTagRenderer: r, html: isHTML, now: r.Now, seen: make(map[string]bool)}
+	if state.now.IsZero() {
+		state.now = time.Now()
+	}
+
+	for _, problem := range problems {
+		state.unresolve(problem.token.Text)
+	}
+	state.nodes(nodes)
+
+	return &MergeTagRenderResult{Output: state.out.String(), Unresolved: state.unresolved}
+}
+
+func (state *mergeRender) unresolve(tag string) {
+	if !state.seen[tag] {
+		state.seen[tag] = true
+		state.unresolved = append(state.unresolved, tag)
+	}
+}
+
+func (state *mergeRender) nodes(nodes []mergeNode) {
+	for _, node := range nodes {
+		switch {
+		case node.block != nil:
+			state.block(node.block)
+		case node.token.Tag:
+			state.tag(node.token)
+		default:
+			state.out.WriteString(node.token.Text)
+		}
+	}
+}
+
+func (state *mergeRender) block(block *mergeBlock) {
+	for _, branch := range block.branches {
+		if branch.cond == nil || state.condition(branch.cond) {
+			state.nodes(branch.body)
+			return
+		}
+	}
+}
+
+func (state *mergeRender) tag(token *mergeToken) {
+	value, ok := state.expand(token)
+	if !ok {
+		state.unresolve(token.Text)
+		state.out.WriteString(token.Text)
+		return
+	}
+
+	state.out.WriteString(value)
+}
+
+// expand returns the output for a single tag, escaped for HTML content.
+func (state *mergeRender) expand(token *mergeToken) (string, bool) {
+	full := token.Name
+	if token.Arg != "" {
+		full += ":" + strings.ToUpper(token.Arg)
+	}
+	if value, ok := state.system(full); ok {
+		return state.escape(value), true
+	}
+	if systemMergeTags[full] {
+		return "", false
+	}
+
+	switch token.Name {
+	case "DATE":
+		return state.escape(formatPHPDate(state.now, token.Arg)), true
+	case "UPPER", "LOWER", "TITLE", "HTML", "URL":
+		value, ok := state.field(token.Arg)
+		if !ok {
+			return "", false
+		}
+		switch token.Name {
+		case "UPPER":
+			return state.escape(strings.ToUpper(value)), true
+		case "LOWER":
+			return state.escape(strings.ToLower(value)), true
+		case "TITLE":
+			return state.escape(titleCase(value)), true
+		case "URL":
+			return url.QueryEscape(value), true
+		default:
+			return value, true
+		}
+	case "IF", "IFNOT", "ELSEIF", "ELSE", "END", "INTERESTED":
+		// stray conditional tags are reported by the parser
+		return "", false
+	}
+
+	value, ok := state.field(token.Name)
+	if token.Arg != "" && (!ok || value == "") {
+		return state.escape(token.Arg), true
+	}
+
+	return state.escape(value), ok
+}
+
+func (state *mergeRender) escape(value string) string {
+	if state.html {
+		return html.EscapeString(value)
+	}
+
+	return value
+}
+
+// field looks up a merge field or system tag value.
+func (state *mergeRender) field(name string) (string, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if state.Member != nil {
+		for tag, value := range state.Member.MergeFields {
+			if strings.ToUpper(tag) == name {
+				return formatMergeValue(value), true
+			}
+		}
+	}
+
+	return state.system(name)
+}
+
+// system resolves system tags, preferring the configured overrides.
+func (state *mergeRender) system(name string) (string, bool) {
+	if value, ok := state.SystemTags[name]; ok {
+		return value, true
+	}
+
+	member, campaign := state.Member, state.Campaign
+	switch name {
+	case "EMAIL":
+		if member != nil {
+			return member.EmailAddress, true
+		}
+	case "UNIQID":
+		if member != nil {
+			return member.UniqueEmailID, true
+		}
+	case "ARCHIVE":
+		if campaign != nil && campaign.LongArchiveUrl != "" {
+			return campaign.LongArchiveUrl, true
+		}
+		return "#archive", true
+	case "ARCHIVE_LINK_SHORT":
+		if campaign != nil && campaign.ArchiveUrl != "" {
+			return campaign.ArchiveUrl, true
+		}
+		return "#archive", true
+	case "UNSUB":
+		return "#unsubscribe", true
+	case "UPDATE_PROFILE":
+		return "#update-profile", true
+	case "FORWARD":
+		return "#forward", true
+	case "MC:SUBJECT":
+		if campaign != nil {
+			return campaign.Settings.SubjectLine, true
+		}
+	case "MC_PREVIEW_TEXT":
+		if campaign != nil {
+			return campaign.Settings.PreviewText, true
+		}
+	case "CAMPAIGN_UID":
+		if campaign != nil {
+			return campaign.ID, true
+		}
+	case "LIST:NAME":
+		if campaign != nil && campaign.Recipients.ListName != "" {
+			return campaign.Recipients.ListName, true
+		}
+	case "MC:DATE":
+		return state.now.Format("01/02/2006"), true
+	case "CURRENT_YEAR":
+		return strconv.Itoa(state.now.Year()), true
+	}
+
+	return "", false
+}
+
+// condition evaluates IF, IFNOT, ELSEIF and INTERESTED conditions.
+func (state *mergeRender) condition(token *mergeToken) bool {
+	if token.Name == "INTERESTED" {
+		return state.interested(token.Arg)
+	}
+
+	result := state.compare(token.Arg)
+	if token.Name == "IFNOT" {
+		return !result
+	}
+
+	return result
+}
+
+var mergeConditionOps = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+func (state *mergeRender) compare(expr string) bool {
+	for _, op := range mergeConditionOps {
+		index := strings.Index(expr, op)
+		if index < 0 {
+			continue
+		}
+
+		value, _ := state.field(expr[:index])
+		want := strings.TrimSpace(expr[index+len(op):])
+
+		a, errA := strconv.ParseFloat(value, 64)
+		b, errB := strconv.ParseFloat(want, 64)
+		numeric := errA == nil && errB == nil
+
+		switch op {
+		case "=":
+			return value == want || (numeric && a == b)
+		case "!=":
+			return value != want && !(numeric && a == b)
+		case ">":
+			return numeric && a > b || !numeric && value > want
+		case "<":
+			return numeric && a < b || !numeric && value < want
+		case ">=":
+			return numeric && a >= b || !numeric && value >= want
+		case "<=":
+			return numeric && a <= b || !numeric && value <= want
+		}
+	}
+
+	value, _ := state.field(expr)
+	return value != ""
+}
+
+// interested handles *|INTERESTED:Category:Interest A,Interest B|*. Without
+// interest names any interest in the category matches.
+func (state *mergeRender) interested(arg string) bool {
+	if state.Member == nil {
+		return false
+	}
+
+	category, names, _ := strings.Cut(arg, ":")
+	category = strings.TrimSpace(category)
+
+	var wanted []string
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			wanted = append(wanted, strings.ToLower(name))
+		}
+	}
+
+	for _, interest := range state.Interests {
+		if !strings.EqualFold(interest.Category, category) || !state.Member.Interests[interest.ID] {
+			continue
+		}
+		if len(wanted) == 0 || containsString(wanted, strings.ToLower(interest.Name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func titleCase(value string) string {
+	words := strings.Fields(strings.ToLower(value))
+	for i, word := range words {
+		r, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(r)) + word[size:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// formatPHPDate formats t using the PHP date() characters Mailchimp supports
+// in *|DATE:format|* tags. Unknown characters are copied as is.
+func formatPHPDate(t time.Time, format string) string {
+	if format == "" {
+		format = "F j, Y"
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch c {
+		case 'd':
+			out.WriteString(t.Format("02"))
+		case 'D':
+			out.WriteString(t.Format("Mon"))
+		case 'j':
+			out.WriteString(strconv.Itoa(t.Day()))
+		case 'l':
+			out.WriteString(t.Format("Monday"))
+		case 'N':
+			out.WriteString(strconv.Itoa((int(t.Weekday())+6)%7 + 1))
+		case 'S':
+			out.WriteString(ordinalSuffix(t.Day()))
+		case 'w':
+			out.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'z':
+			out.WriteString(strconv.Itoa(t.YearDay() - 1))
+		case 'W':
+			_, week := t.ISOWeek()
+			out.WriteString(fmt.Sprintf("%02d", week))
+		case 'F':
+			out.WriteString(t.Format("January"))
+		case 'm':
+			out.WriteString(t.Format("01"))
+		case 'M':
+			out.WriteString(t.Format("Jan"))
+		case 'n':
+			out.WriteString(strconv.Itoa(int(t.Month())))
+		case 't':
+			out.WriteString(strconv.Itoa(time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()))
+		case 'Y':
+			out.WriteString(strconv.Itoa(t.Year()))
+		case 'y':
+			out.WriteString(t.Format("06"))
+		case 'a':
+			out.WriteString(t.Format("pm"))
+		case 'A':
+			out.WriteString(t.Format("PM"))
+		case 'g':
+			out.WriteString(t.Format("3"))
+		case 'G':
+			out.WriteString(strconv.Itoa(t.Hour()))
+		case 'h':
+			out.WriteString(t.Format("03"))
+		case 'H':
+			out.WriteString(t.Format("15"))
+		case 'i':
+			out.WriteString(t.Format("04"))
+		case 's':
+			out.WriteString(t.Format("05"))
+		case '\\':
+			if i+1 < len(format) {
+				i++
+				out.WriteByte(format[i])
+			}
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	return out.String()
+}
+
+func ordinalSuffix(day int) string {
+	if day >= 11 && day <= 13 {
+		return "th"
+	}
+
+	switch day % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
@@ -0,0 +1,60 @@
+package gochimp3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRenderer() *MergeTagRenderer {
+	member := new(Member)
+	member.EmailAddress = "ada@example.com"
+	member.MergeFields = map[string]any{"FNAME": "ada", "LNAME": "", "AGE": 36}
+	member.Interests = map[string]bool{"i1": true, "i2": false}
+
+	return &MergeTagRenderer{
+		Member: member,
+		Interests: []MergeTagInterest{
+			{ID: "i1", Category: "Topics", Name: "Go"},
+			{ID: "i2", Category: "Topics", Name: "Rust"},
+		},
+		Now: time.Date(2024, time.March, 1, 9, 5, 0, 0, time.UTC),
+	}
+}
+
+func TestRenderMergeTags(t *testing.T) {
+	tests := map[string]string{
+		"Hi *|TITLE:FNAME|*":                                      "Hi Ada",
+		"*|LNAME:friend|* *|EMAIL|*":                              "friend ada@example.com",
+		"*|DATE:F jS, Y|*":                                        "March 1st, 2024",
+		"*|IF:AGE>=18|*adult*|ELSE:|*minor*|END:IF|*":             "adult",
+		"*|IF:LNAME|*x*|ELSEIF:FNAME=ada|*y*|END:IF|*":            "y",
+		"*|IFNOT:LNAME|*none*|END:IF|*":                           "none",
+		"*|INTERESTED:Topics:Rust|*r*|ELSE:|*g*|END:INTERESTED|*": "g",
+		"*|INTERESTED:Topics|*any*|END:INTERESTED|*":              "any",
+		"<a href=\"*|UNSUB|*\">":                                  "<a href=\"#unsubscribe\">",
+	}
+
+	for content, want := range tests {
+		result := testRenderer().RenderText(content)
+		assert.Equal(t, want, result.Output, content)
+		assert.Empty(t, result.Unresolved, content)
+	}
+}
+
+func TestRenderMergeTagsUnresolved(t *testing.T) {
+	renderer := testRenderer()
+	renderer.Member.MergeFields["FNAME"] = "<b>"
+
+	result := renderer.RenderHTML("*|FNAME|* *|COMPANY|* *|LIST:COMPANY|* *|IF:FNAME|*open")
+	assert.Equal(t, "&lt;b&gt; *|COMPANY|* *|LIST:COMPANY|* open", result.Output)
+	assert.Equal(t, []string{"*|IF:FNAME|*", "*|COMPANY|*", "*|LIST:COMPANY|*"}, result.Unresolved)
+}
+
+func TestRenderMergeTagsDefaultEscaped(t *testing.T) {
+	content := "*|LNAME:Tom & <Jerry>|*"
+
+	assert.Equal(t, "Tom &amp; &lt;Jerry&gt;", testRenderer().RenderHTML(content).Output)
+	assert.Equal(t, "Tom & <Jerry>", testRenderer().RenderText(content).Output)
+}
@@ -0,0 +1,227 @@
+package gochimp3
+
+import (
+	"strings"
+)
+
+// systemMergeTags are resolved by Mailchimp on every list, independent of the
+// list's merge fields.
+var systemMergeTags = map[string]bool{
+	"UNSUB":                  true,
+	"UPDATE_PROFILE":         true,
+	"ARCHIVE":                true,
+	"ARCHIVE_LINK_SHORT":     true,
+	"FORWARD":                true,
+	"EMAIL":                  true,
+	"UNIQID":                 true,
+	"CAMPAIGN_UID":           true,
+	"CURRENT_YEAR":           true,
+	"REWARDS":                true,
+	"REWARDS_TEXT":           true,
+	"ABOUT_LIST":             true,
+	"MC_PREVIEW_TEXT":        true,
+	"MC_LANGUAGE":            true,
+	"MC_LANGUAGE_LABEL":      true,
+	"MC:SUBJECT":             true,
+	"MC:DATE":                true,
+	"MC:TOC":                 true,
+	"MC:TRANSLATE":           true,
+	"LIST:NAME":              true,
+	"LIST:COMPANY":           true,
+	"LIST:DESCRIPTION":       true,
+	"LIST:ADDRESS":           true,
+	"LIST:ADDRESSLINE":       true,
+	"LIST:ADDRESS_VCARD":     true,
+	"LIST:PHONE":             true,
+	"LIST:URL":               true,
+	"LIST:RECIPIENTS":        true,
+	"LIST:SUBSCRIBERS":       true,
+	"LIST:SUBSCRIBE":         true,
+	"HTML:LIST_ADDRESS_HTML": true,
+	"LIST_ADDRESS_HTML":      true,
+	"USER:COMPANY":           true,
+	"USER:ADDRESS":           true,
+	"USER:ADDRESS_HTML":      true,
+	"USER:PHONE":             true,
+	"USER:URL":               true,
+	"FACEBOOK:PROFILEURL":    true,
+	"TWITTER:PROFILEURL":     true,
+}
+
+// ------------------------------------------------------------------------------------------------
+// Tokenizer
+// ------------------------------------------------------------------------------------------------
+
+// mergeToken is either literal text or a single merge tag. Start and End are
+// byte offsets into the tokenized content.
+type mergeToken struct {
+	Text  string // the raw text, including the *| |* delimiters for tags
+	Tag   bool
+	Name  string // upper cased part before the first colon
+	Arg   string // part after the first colon, empty when there is none
+	Start int
+	End   int
+}
+
+// tokenizeMergeTags splits content into text and tag tokens. Openers without
+// a closer are kept as text and reported as malformed.
+func tokenizeMergeTags(content string) (tokens []mergeToken, malformed []mergeToken) {
+	text := 0
+	flush := func(end int) {
+		if end > text {
+			tokens = append(tokens, mergeToken{Text: content[text:end], Start: text, End: end})
+		}
+	}
+
+	for i := 0; i < len(content); {
+		open := strings.Index(content[i:], "*|")
+		if open < 0 {
+			break
+		}
+		open += i
+
+		inner := content[open+2:]
+		closer := strings.Index(inner, "|*")
+		if closer < 0 || strings.Contains(inner[:closer], "*|") || strings.Contains(inner[:closer], "|") {
+			malformed = append(malformed, mergeToken{Text: "*|", Start: open, End: open + 2})
+			i = open + 2
+			continue
+		}
+
+		flush(open)
+
+		end := open + 2 + closer + 2
+		token := mergeToken{Text: content[open:end], Tag: true, Start: open, End: end}
+		name, arg, _ := strings.Cut(inner[:closer], ":")
+		token.Name = strings.ToUpper(strings.TrimSpace(name))
+		token.Arg = strings.TrimSpace(arg)
+		tokens = append(tokens, token)
+
+		text = end
+		i = end
+	}
+	flush(len(content))
+
+	return tokens, malformed
+}
+
+// ------------------------------------------------------------------------------------------------
+// Parser
+// ------------------------------------------------------------------------------------------------
+
+// mergeNode is either a token or a conditional block.
+type mergeNode struct {
+	token *mergeToken
+	block *mergeBlock
+}
+
+// mergeBlock is an IF, IFNOT or INTERESTED block with its ELSEIF and ELSE
+// branches.
+type mergeBlock struct {
+	open     *mergeToken
+	branches []mergeBranch
+	closed   bool
+}
+
+type mergeBranch struct {
+	cond *mergeToken // nil for the ELSE branch
+	body []mergeNode
+}
+
+func (block *mergeBlock) kind() string {
+	if block.open.Name == "INTERESTED" {
+		return "INTERESTED"
+	}
+
+	return "IF"
+}
+
+func (block *mergeBlock) hasElse() bool {
+	return block.branches[len(block.branches)-1].cond == nil
+}
+
+// mergeProblem is a structural problem found while parsing, e.g. an ELSE
+// outside of a conditional block.
+type mergeProblem struct {
+	token   *mergeToken
+	message string
+}
+
+// parseMergeTags builds the conditional block tree of the tokens. Parsing is
+// lenient: stray tags are kept as plain tags and reported.
+func parseMergeTags(tokens []mergeToken) ([]mergeNode, []mergeProblem) {
+	var root []mergeNode
+	var stack []*mergeBlock
+	var problems []mergeProblem
+
+	body := func() *[]mergeNode {
+		if len(stack) == 0 {
+			return &root
+		}
+		block := stack[len(stack)-1]
+		return &block.branches[len(block.branches)-1].body
+	}
+
+	for i := range tokens {
+		token := &tokens[i]
+		if !token.Tag {
+			*body() = append(*body(), mergeNode{token: token})
+			continue
+		}
+
+		var top *mergeBlock
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		switch token.Name {
+		case "IF", "IFNOT", "INTERESTED":
+			block := &mergeBlock{open: token, branches: []mergeBranch{{cond: token}}}
+			*body() = append(*body(), mergeNode{block: block})
+			stack = append(stack, block)
+
+			if token.Arg == "" {
+				problems = append(problems, mergeProblem{token, token.Name + " without a condition"})
+			}
+			continue
+
+		case "ELSEIF":
+			if top != nil && !top.hasElse() {
+				top.branches = append(top.branches, mergeBranch{cond: token})
+				if token.Arg == "" {
+					problems = append(problems, mergeProblem{token, "ELSEIF without a condition"})
+				}
+				continue
+			}
+			problems = append(problems, mergeProblem{token, "ELSEIF outside of an IF block"})
+
+		case "ELSE":
+			if top != nil && !top.hasElse() {
+				top.branches = append(top.branches, mergeBranch{})
+				continue
+			}
+			problems = append(problems, mergeProblem{token, "ELSE outside of an IF block"})
+
+		case "END":
+			kind := strings.ToUpper(token.Arg)
+			if top != nil && top.kind() == kind {
+				top.closed = true
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			if top != nil {
+				problems = append(problems, mergeProblem{token, "END:" + kind + " does not close " + top.open.Text})
+			} else {
+				problems = append(problems, mergeProblem{token, "END:" + kind + " without an open block"})
+			}
+		}
+
+		*body() = append(*body(), mergeNode{token: token})
+	}
+
+	for _, block := range stack {
+		problems = append(problems, mergeProblem{block.open, block.open.Text + " is never closed with END:" + block.kind()})
+	}
+
+	return root, problems
+}
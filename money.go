@@ -0,0 +1,181 @@
+package gochimp3
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// moneyScale is the number of Money units per currency unit. Four decimals
+// cover every ISO 4217 currency and leave room for per recipient averages.
+const (
+	moneyScale    = 10000
+	moneyDecimals = 4
+)
+
+// Money is a fixed point decimal amount with four decimals. It decodes from
+// JSON numbers and strings without going through float64, so cents are never
+// lost.
+type Money int64
+
+// NewMoney returns the amount of whole units and cents, e.g. NewMoney(12, 50)
+// for 12.50.
+func NewMoney(units, cents int64) Money {
+	if units < 0 {
+		cents = -cents
+	}
+	return Money(units*moneyScale + cents*moneyScale/100)
+}
+
+// MoneyFromFloat rounds f to the nearest Money amount.
+func MoneyFromFloat(f float64) Money {
+	return Money(math.Round(f * moneyScale))
+}
+
+// ParseMoney parses a decimal string like "12.5" or "-0.0125". Digits beyond
+// the fourth decimal are rounded half away from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	value := s
+	negative := false
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		negative = value[0] == '-'
+		value = value[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return 0, errors.Errorf("invalid money amount %q", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid money amount %q", s)
+	}
+	for _, c := range fraction {
+		if c < '0' || c > '9' {
+			return 0, errors.Errorf("invalid money amount %q", s)
+		}
+	}
+
+	roundUp := len(fraction) > moneyDecimals && fraction[moneyDecimals] >= '5'
+	if len(fraction) > moneyDecimals {
+		fraction = fraction[:moneyDecimals]
+	}
+	fraction += strings.Repeat("0", moneyDecimals-len(fraction))
+	decimals, _ := strconv.ParseInt(fraction, 10, 64)
+
+	amount := units*moneyScale + decimals
+	if roundUp {
+		amount++
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money(amount), nil
+}
+
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+func (m Money) Mul(n int) Money {
+	return m * Money(n)
+}
+
+// Div divides the amount by n, rounding half away from zero. Dividing by zero
+// returns zero.
+func (m Money) Div(n int) Money {
+	if n == 0 {
+		return 0
+	}
+
+	q, r := int64(m)/int64(n), int64(m)%int64(n)
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= absInt64(int64(n)) {
+		if (m < 0) != (n < 0) {
+			q--
+		} else {
+			q++
+		}
+	}
+
+	return Money(q)
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+// Cents returns the amount rounded to whole cents.
+func (m Money) Cents() int64 {
+	return int64(m.Div(moneyScale / 100))
+}
+
+// String formats the amount with at least two decimals, e.g. "12.50" or
+// "0.0125".
+func (m Money) String() string {
+	amount := int64(m)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	fraction := strconv.FormatInt(amount%moneyScale+moneyScale, 10)[1:]
+	fraction = strings.TrimRight(fraction, "0")
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+
+	return sign + strconv.FormatInt(amount/moneyScale, 10) + "." + fraction
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*m = 0
+		return nil
+	}
+
+	// exponent notation is valid JSON but rare, parse it as a float
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid money amount %s", data)
+		}
+		*m = MoneyFromFloat(f)
+		return nil
+	}
+
+	amount, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+
+	*m = amount
+	return nil
+}
+
+func absInt64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
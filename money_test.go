@@ -0,0 +1,55 @@
+package gochimp3
+
+import (
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	cases := map[string]string{
+		"12":       "12.00",
+		"12.5":     "12.50",
+		"0.1":      "0.10",
+		".25":      "0.25",
+		"-3.07":    "-3.07",
+		"0.0125":   "0.0125",
+		"1.000051": "1.0001",
+		"-1.00005": "-1.0001",
+	}
+	for input, expected := range cases {
+		m, err := ParseMoney(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, m.String(), input)
+	}
+
+	_, err := ParseMoney("1.2x")
+	assert.Error(t, err)
+	_, err = ParseMoney("")
+	assert.Error(t, err)
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	assert.Equal(t, "12.50", NewMoney(12, 50).String())
+	assert.Equal(t, "-12.50", NewMoney(-12, 50).String())
+	assert.Equal(t, "0.3333", NewMoney(1, 0).Div(3).String())
+	assert.Equal(t, "-0.6667", NewMoney(-2, 0).Div(3).String())
+	assert.Equal(t, Money(0), NewMoney(1, 0).Div(0))
+	assert.Equal(t, int64(1250), NewMoney(12, 50).Cents())
+
+	// 0.1 + 0.2 is exact
+	assert.Equal(t, NewMoney(0, 30), MoneyFromFloat(0.1).Add(MoneyFromFloat(0.2)))
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var ecommerce ReportEcommerce
+	err := json.Unmarshal([]byte(`{"total_orders":2,"total_spent":"19.99","total_revenue":1234567.89}`), &ecommerce)
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(19, 99), ecommerce.TotalSpent)
+	assert.Equal(t, NewMoney(1234567, 89), ecommerce.TotalRevenue)
+
+	data, err := json.Marshal(ecommerce)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"total_revenue":1234567.89`)
+}
@@ -0,0 +1,155 @@
+package gochimp3
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ReportRollup combines a campaign's report with its sub-reports. Totals are
+// summed over resends, a variate parent already includes its combinations.
+// Recipients, opens and clicks count every recipient once no matter how many
+// of the campaigns reached them.
+type ReportRollup struct {
+	CampaignID string
+	Reports    []*CampaignReport // the parent report first
+
+	EmailsSent   int
+	Bounces      int
+	Unsubscribed int
+	AbuseReports int
+	OpensTotal   int
+	ClicksTotal  int
+	TotalOrders  int
+	TotalRevenue Money
+
+	// Recipients and Delivered count distinct recipients, a recipient is
+	// delivered when at least one of the emails didn't bounce
+	Recipients   int
+	Delivered    int
+	UniqueOpens  int
+	UniqueClicks int
+
+	OpenRate  float64
+	ClickRate float64
+}
+
+// RollupReports combines the reports, de-duplicating recipients by subscriber
+// hash from the sent-to entries and email activity of all the campaigns.
+// Without sent-to entries the recipients are estimated from the emails sent.
+func RollupReports(reports []*CampaignReport, sentTo []SentTo, activity []EmailActivityRow) *ReportRollup {
+	rollup := &ReportRollup{Reports: reports}
+	if len(reports) > 0 {
+		rollup.CampaignID = reports[0].ID
+	}
+
+	totals := reports
+	if len(reports) > 0 && reports[0].Type == CampaignTypeVariate {
+		totals = reports[:1]
+	}
+
+	for _, report := range totals {
+		rollup.EmailsSent += report.EmailsSent
+		rollup.Bounces += report.Bounces.Total()
+		rollup.Unsubscribed += report.Unsubscribed
+		rollup.AbuseReports += report.AbuseReports
+		rollup.OpensTotal += report.Opens.OpensTotal
+		rollup.ClicksTotal += report.Clicks.ClicksTotal
+		rollup.TotalOrders += report.Ecommerce.TotalOrders
+		rollup.TotalRevenue = rollup.TotalRevenue.Add(report.Ecommerce.TotalRevenue)
+	}
+
+	delivered := make(map[string]bool)
+	for _, sent := range sentTo {
+		hash := sent.EmailID
+		if hash == "" {
+			hash = SubscriberHash(sent.EmailAddress)
+		}
+		delivered[hash] = delivered[hash] || !sent.Bounced()
+	}
+
+	if len(delivered) > 0 {
+		rollup.Recipients = len(delivered)
+		for _, ok := range delivered {
+			if ok {
+				rollup.Delivered++
+			}
+		}
+	} else {
+		rollup.Recipients = rollup.EmailsSent
+		rollup.Delivered = rollup.EmailsSent - rollup.Bounces
+	}
+
+	openers := make(map[string]bool)
+	clickers := make(map[string]bool)
+	for _, row := range activity {
+		switch row.Action {
+		case EmailActivityOpen:
+			openers[row.SubscriberHash] = true
+		case EmailActivityClick:
+			clickers[row.SubscriberHash] = true
+		}
+	}
+
+	rollup.UniqueOpens = len(openers)
+	rollup.UniqueClicks = len(clickers)
+	rollup.OpenRate = ratio(float64(rollup.UniqueOpens), rollup.Delivered)
+	rollup.ClickRate = ratio(float64(rollup.UniqueClicks), rollup.Delivered)
+
+	return rollup
+}
+
+// RollupCampaignReport fetches the campaign's report, its sub-reports and the
+// sent-to and email activity of each to build the roll-up.
+func (api *API) RollupCampaignReport(ctx context.Context, id string) (*ReportRollup, error) {
+	report, err := api.GetReport(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	subReports, err := api.GetSubReports(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []*CampaignReport{report}
+	for i := range subReports.Reports {
+		reports = append(reports, &subReports.Reports[i])
+	}
+
+	var sentTo []SentTo
+	var activity []EmailActivityRow
+	for _, r := range reports {
+		params := &ExtendedQueryParams{Count: maxPageSize}
+		for {
+			page, err := api.GetSentTo(ctx, r.ID, params)
+			if err != nil {
+				return nil, errors.Wrapf(err, "sent-to of campaign %s", r.ID)
+			}
+
+			sentTo = append(sentTo, page.SentTo...)
+			params.Offset += len(page.SentTo)
+			if len(page.SentTo) == 0 || params.Offset >= page.TotalItems {
+				break
+			}
+		}
+
+		it := api.IterateEmailActivity(r.ID, nil)
+		for it.Next(ctx) {
+			activity = append(activity, *it.Row())
+		}
+		if err := it.Err(); err != nil {
+			return nil, errors.Wrapf(err, "email activity of campaign %s", r.ID)
+		}
+	}
+
+	return RollupReports(reports, sentTo, activity), nil
+}
+
+func (campaign *CampaignResponse) RollupReport(ctx context.Context) (*ReportRollup, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.RollupCampaignReport(ctx, campaign.ID)
+}
@@ -0,0 +1,995 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	reportsPath             = "/reports"
+	singleReportPath        = reportsPath + "/%s"
+	openDetailsPath         = singleReportPath + "/open-details"
+	clickDetailsPath        = singleReportPath + "/click-details"
+	singleClickDetailPath   = clickDetailsPath + "/%s"
+	clickDetailMembersPath  = singleClickDetailPath + "/members"
+	clickDetailMemberPath   = clickDetailMembersPath + "/%s"
+	emailActivityPath       = singleReportPath + "/email-activity"
+	memberEmailActivityPath = emailActivityPath + "/%s"
+	unsubscribedPath        = singleReportPath + "/unsubscribed"
+	singleUnsubscribedPath  = unsubscribedPath + "/%s"
+	sentToPath              = singleReportPath + "/sent-to"
+	singleSentToPath        = sentToPath + "/%s"
+	reportAbusePath         = singleReportPath + "/abuse-reports"
+	singleReportAbusePath   = reportAbusePath + "/%s"
+	reportAdvicePath        = singleReportPath + "/advice"
+	domainPerformancePath   = singleReportPath + "/domain-performance"
+	locationsPath           = singleReportPath + "/locations"
+	eepurlPath              = singleReportPath + "/eepurl"
+	productActivityPath     = singleReportPath + "/ecommerce-product-activity"
+	subReportsPath          = singleReportPath + "/sub-reports"
+
+	EmailActivityOpen   = "open"
+	EmailActivityClick  = "click"
+	EmailActivityBounce = "bounce"
+
+	SentToStatusSent = "sent"
+	SentToStatusHard = "hard"
+	SentToStatusSoft = "soft"
+
+	AdviceTypeNegative = "negative"
+	AdviceTypePositive = "positive"
+	AdviceTypeNeutral  = "neutral"
+)
+
+// ------------------------------------------------------------------------------------------------
+// Query Params
+// ------------------------------------------------------------------------------------------------
+
+type ReportQueryParams struct {
+	ExtendedQueryParams
+
+	Type           string
+	BeforeSendTime string
+	SinceSendTime  string
+}
+
+func (q *ReportQueryParams) Params() map[string]string {
+	m := q.ExtendedQueryParams.Params()
+	m["type"] = q.Type
+	m["before_send_time"] = q.BeforeSendTime
+	m["since_send_time"] = q.SinceSendTime
+	return m
+}
+
+// ReportActivityQueryParams pages through open details and email activity.
+// Since only returns activity after the given ISO 8601 time.
+type ReportActivityQueryParams struct {
+	ExtendedQueryParams
+
+	Since string
+}
+
+func (q *ReportActivityQueryParams) Params() map[string]string {
+	m := q.ExtendedQueryParams.Params()
+	m["since"] = q.Since
+	return m
+}
+
+// ------------------------------------------------------------------------------------------------
+// Reports
+// ------------------------------------------------------------------------------------------------
+
+type ListOfReports struct {
+	baseList
+
+	Reports []CampaignReport `json:"reports"`
+}
+
+type CampaignReport struct {
+	withLinks
+
+	ID             string               `json:"id"`
+	CampaignTitle  string               `json:"campaign_title"`
+	Type           string               `json:"type"`
+	ListID         string               `json:"list_id"`
+	ListIsActive   bool                 `json:"list_is_active"`
+	ListName       string               `json:"list_name"`
+	SubjectLine    string               `json:"subject_line"`
+	PreviewText    string               `json:"preview_text"`
+	EmailsSent     int                  `json:"emails_sent"`
+	AbuseReports   int                  `json:"abuse_reports"`
+	Unsubscribed   int                  `json:"unsubscribed"`
+	SendTime       string               `json:"send_time"`
+	RSSLastSend    string               `json:"rss_last_send"`
+	Bounces        ReportBounces        `json:"bounces"`
+	Forwards       ReportForwards       `json:"forwards"`
+	Opens          ReportOpens          `json:"opens"`
+	Clicks         ReportClicks         `json:"clicks"`
+	FacebookLikes  ReportFacebookLikes  `json:"facebook_likes"`
+	IndustryStats  ReportIndustryStats  `json:"industry_stats"`
+	ListStats      ReportListStats      `json:"list_stats"`
+	ABSplit        *ReportABSplit       `json:"ab_split,omitempty"`
+	Timewarp       []ReportTimewarp     `json:"timewarp"`
+	Timeseries     []ReportTimeseries   `json:"timeseries"`
+	ShareReport    ReportShare          `json:"share_report"`
+	Ecommerce      ReportEcommerce      `json:"ecommerce"`
+	DeliveryStatus ReportDeliveryStatus `json:"delivery_status"`
+
+	api *API
+}
+
+func (report *CampaignReport) CanMakeRequest() error {
+	if report.ID == "" {
+		return errors.New("No ID provided on report")
+	}
+
+	return nil
+}
+
+type ReportBounces struct {
+	HardBounces  int `json:"hard_bounces"`
+	SoftBounces  int `json:"soft_bounces"`
+	SyntaxErrors int `json:"syntax_errors"`
+}
+
+// Total returns the sum of hard, soft and syntax error bounces.
+func (bounces ReportBounces) Total() int {
+	return bounces.HardBounces + bounces.SoftBounces + bounces.SyntaxErrors
+}
+
+type ReportForwards struct {
+	ForwardsCount int `json:"forwards_count"`
+	ForwardsOpens int `json:"forwards_opens"`
+}
+
+type ReportOpens struct {
+	OpensTotal               int     `json:"opens_total"`
+	UniqueOpens              int     `json:"unique_opens"`
+	OpenRate                 float64 `json:"open_rate"`
+	LastOpen                 string  `json:"last_open"`
+	ProxyExcludedOpens       int     `json:"proxy_excluded_opens"`
+	ProxyExcludedUniqueOpens int     `json:"proxy_excluded_unique_opens"`
+	ProxyExcludedOpenRate    float64 `json:"proxy_excluded_open_rate"`
+}
+
+type ReportClicks struct {
+	ClicksTotal            int     `json:"clicks_total"`
+	UniqueClicks           int     `json:"unique_clicks"`
+	UniqueSubscriberClicks int     `json:"unique_subscriber_clicks"`
+	ClickRate              float64 `json:"click_rate"`
+	LastClick              string  `json:"last_click"`
+}
+
+type ReportFacebookLikes struct {
+	RecipientLikes int `json:"recipient_likes"`
+	UniqueLikes    int `json:"unique_likes"`
+	FacebookLikes  int `json:"facebook_likes"`
+}
+
+type ReportIndustryStats struct {
+	Type       string  `json:"type"`
+	OpenRate   float64 `json:"open_rate"`
+	ClickRate  float64 `json:"click_rate"`
+	BounceRate float64 `json:"bounce_rate"`
+	UnopenRate float64 `json:"unopen_rate"`
+	UnsubRate  float64 `json:"unsub_rate"`
+	AbuseRate  float64 `json:"abuse_rate"`
+}
+
+type ReportListStats struct {
+	SubRate   float64 `json:"sub_rate"`
+	UnsubRate float64 `json:"unsub_rate"`
+	OpenRate  float64 `json:"open_rate"`
+	ClickRate float64 `json:"click_rate"`
+}
+
+type ReportABSplit struct {
+	A ReportABSplitGroup `json:"a"`
+	B ReportABSplitGroup `json:"b"`
+}
+
+type ReportABSplitGroup struct {
+	Bounces         int    `json:"bounces"`
+	AbuseReports    int    `json:"abuse_reports"`
+	Unsubs          int    `json:"unsubs"`
+	RecipientClicks int    `json:"recipient_clicks"`
+	Forwards        int    `json:"forwards"`
+	ForwardsOpens   int    `json:"forwards_opens"`
+	Opens           int    `json:"opens"`
+	LastOpen        string `json:"last_open"`
+	UniqueOpens     int    `json:"unique_opens"`
+}
+
+type ReportTimewarp struct {
+	GMTOffset    int    `json:"gmt_offset"`
+	Opens        int    `json:"opens"`
+	LastOpen     string `json:"last_open"`
+	UniqueOpens  int    `json:"unique_opens"`
+	Clicks       int    `json:"clicks"`
+	LastClick    string `json:"last_click"`
+	UniqueClicks int    `json:"unique_clicks"`
+	Bounces      int    `json:"bounces"`
+}
+
+type ReportTimeseries struct {
+	Timestamp        string `json:"timestamp"`
+	EmailsSent       int    `json:"emails_sent"`
+	UniqueOpens      int    `json:"unique_opens"`
+	RecipientsClicks int    `json:"recipients_clicks"`
+}
+
+type ReportShare struct {
+	ShareURL      string `json:"share_url"`
+	SharePassword string `json:"share_password"`
+}
+
+type ReportEcommerce struct {
+	TotalOrders  int    `json:"total_orders"`
+	TotalSpent   Money  `json:"total_spent"`
+	TotalRevenue Money  `json:"total_revenue"`
+	CurrencyCode string `json:"currency_code"`
+}
+
+type ReportDeliveryStatus struct {
+	Enabled        bool   `json:"enabled"`
+	CanCancel      bool   `json:"can_cancel"`
+	Status         string `json:"status"`
+	EmailsSent     int    `json:"emails_sent"`
+	EmailsCanceled int    `json:"emails_canceled"`
+}
+
+func (api *API) GetReports(ctx context.Context, params *ReportQueryParams) (*ListOfReports, error) {
+	response := new(ListOfReports)
+
+	err := api.Request(ctx, http.MethodGet, reportsPath, params, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range response.Reports {
+		response.Reports[i].api = api
+	}
+
+	return response, nil
+}
+
+func (api *API) GetReport(ctx context.Context, id string, params *BasicQueryParams) (*CampaignReport, error) {
+	endpoint := fmt.Sprintf(singleReportPath, id)
+
+	response := new(CampaignReport)
+	response.api = api
+
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Open Details
+// ------------------------------------------------------------------------------------------------
+
+type ListOfOpenDetails struct {
+	baseList
+
+	CampaignID              string             `json:"campaign_id"`
+	TotalOpens              int                `json:"total_opens"`
+	TotalProxyExcludedOpens int                `json:"total_proxy_excluded_opens"`
+	Members                 []OpenDetailMember `json:"members"`
+}
+
+type OpenDetailMember struct {
+	withLinks
+
+	CampaignID              string         `json:"campaign_id"`
+	ListID                  string         `json:"list_id"`
+	ListIsActive            bool           `json:"list_is_active"`
+	ContactStatus           string         `json:"contact_status"`
+	EmailID                 string         `json:"email_id"`
+	EmailAddress            string         `json:"email_address"`
+	MergeFields             map[string]any `json:"merge_fields"`
+	VIP                     bool           `json:"vip"`
+	OpensCount              int            `json:"opens_count"`
+	ProxyExcludedOpensCount int            `json:"proxy_excluded_opens_count"`
+	Opens                   []MemberOpen   `json:"opens"`
+}
+
+type MemberOpen struct {
+	Timestamp   string `json:"timestamp"`
+	IsProxyOpen bool   `json:"is_proxy_open"`
+}
+
+func (api *API) GetOpenDetails(ctx context.Context, id string, params *ReportActivityQueryParams) (*ListOfOpenDetails, error) {
+	endpoint := fmt.Sprintf(openDetailsPath, id)
+	response := new(ListOfOpenDetails)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Click Details
+// ------------------------------------------------------------------------------------------------
+
+type ListOfClickDetails struct {
+	baseList
+
+	CampaignID  string        `json:"campaign_id"`
+	URLsClicked []ClickDetail `json:"urls_clicked"`
+}
+
+type ClickDetail struct {
+	withLinks
+
+	ID                    string            `json:"id"`
+	URL                   string            `json:"url"`
+	TotalClicks           int               `json:"total_clicks"`
+	ClickPercentage       float64           `json:"click_percentage"`
+	UniqueClicks          int               `json:"unique_clicks"`
+	UniqueClickPercentage float64           `json:"unique_click_percentage"`
+	LastClick             string            `json:"last_click"`
+	ABSplit               *ClickDetailSplit `json:"ab_split,omitempty"`
+	CampaignID            string            `json:"campaign_id"`
+}
+
+type ClickDetailSplit struct {
+	A ClickDetailSplitGroup `json:"a"`
+	B ClickDetailSplitGroup `json:"b"`
+}
+
+type ClickDetailSplitGroup struct {
+	Clicks                int     `json:"clicks"`
+	ClickPercentage       float64 `json:"click_percentage"`
+	UniqueClicks          int     `json:"unique_clicks"`
+	UniqueClickPercentage float64 `json:"unique_click_percentage"`
+}
+
+type ListOfClickDetailMembers struct {
+	baseList
+
+	CampaignID string              `json:"campaign_id"`
+	Members    []ClickDetailMember `json:"members"`
+}
+
+type ClickDetailMember struct {
+	withLinks
+
+	EmailID       string         `json:"email_id"`
+	EmailAddress  string         `json:"email_address"`
+	MergeFields   map[string]any `json:"merge_fields"`
+	VIP           bool           `json:"vip"`
+	Clicks        int            `json:"clicks"`
+	CampaignID    string         `json:"campaign_id"`
+	URLID         string         `json:"url_id"`
+	ListID        string         `json:"list_id"`
+	ListIsActive  bool           `json:"list_is_active"`
+	ContactStatus string         `json:"contact_status"`
+}
+
+func (api *API) GetClickDetails(ctx context.Context, id string, params *ExtendedQueryParams) (*ListOfClickDetails, error) {
+	endpoint := fmt.Sprintf(clickDetailsPath, id)
+	response := new(ListOfClickDetails)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) GetClickDetail(ctx context.Context, id, linkID string, params *BasicQueryParams) (*ClickDetail, error) {
+	endpoint := fmt.Sprintf(singleClickDetailPath, id, linkID)
+	response := new(ClickDetail)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) GetClickDetailMembers(ctx context.Context, id, linkID string, params *ExtendedQueryParams) (*ListOfClickDetailMembers, error) {
+	endpoint := fmt.Sprintf(clickDetailMembersPath, id, linkID)
+	response := new(ListOfClickDetailMembers)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) GetClickDetailMember(ctx context.Context, id, linkID, email string, params *BasicQueryParams) (*ClickDetailMember, error) {
+	endpoint := fmt.Sprintf(clickDetailMemberPath, id, linkID, SubscriberHash(email))
+	response := new(ClickDetailMember)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Email Activity
+// ------------------------------------------------------------------------------------------------
+
+type ListOfEmailActivity struct {
+	baseList
+
+	CampaignID string          `json:"campaign_id"`
+	Emails     []EmailActivity `json:"emails"`
+}
+
+type EmailActivity struct {
+	withLinks
+
+	CampaignID   string               `json:"campaign_id"`
+	ListID       string               `json:"list_id"`
+	ListIsActive bool                 `json:"list_is_active"`
+	EmailID      string               `json:"email_id"`
+	EmailAddress string               `json:"email_address"`
+	Activity     []EmailActivityEvent `json:"activity"`
+}
+
+type EmailActivityEvent struct {
+	Action    string `json:"action"` // one of the EmailActivity* consts
+	Type      string `json:"type"`   // the bounce type for bounces
+	Timestamp string `json:"timestamp"`
+	URL       string `json:"url"`
+	IP        string `json:"ip"`
+}
+
+func (api *API) GetEmailActivity(ctx context.Context, id string, params *ReportActivityQueryParams) (*ListOfEmailActivity, error) {
+	endpoint := fmt.Sprintf(emailActivityPath, id)
+	response := new(ListOfEmailActivity)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) GetMemberEmailActivity(ctx context.Context, id, email string, params *ReportActivityQueryParams) (*EmailActivity, error) {
+	endpoint := fmt.Sprintf(memberEmailActivityPath, id, SubscriberHash(email))
+	response := new(EmailActivity)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Unsubscribed
+// ------------------------------------------------------------------------------------------------
+
+type ListOfUnsubscribes struct {
+	baseList
+
+	CampaignID   string        `json:"campaign_id"`
+	Unsubscribes []Unsubscribe `json:"unsubscribes"`
+}
+
+type Unsubscribe struct {
+	withLinks
+
+	EmailID      string         `json:"email_id"`
+	EmailAddress string         `json:"email_address"`
+	MergeFields  map[string]any `json:"merge_fields"`
+	VIP          bool           `json:"vip"`
+	Timestamp    string         `json:"timestamp"`
+	Reason       string         `json:"reason"`
+	CampaignID   string         `json:"campaign_id"`
+	ListID       string         `json:"list_id"`
+	ListIsActive bool           `json:"list_is_active"`
+}
+
+func (api *API) GetUnsubscribes(ctx context.Context, id string, params *ExtendedQueryParams) (*ListOfUnsubscribes, error) {
+	endpoint := fmt.Sprintf(unsubscribedPath, id)
+	response := new(ListOfUnsubscribes)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) GetUnsubscribe(ctx context.Context, id, email string, params *BasicQueryParams) (*Unsubscribe, error) {
+	endpoint := fmt.Sprintf(singleUnsubscribedPath, id, SubscriberHash(email))
+	response := new(Unsubscribe)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Sent To
+// ------------------------------------------------------------------------------------------------
+
+type ListOfSentTo struct {
+	baseList
+
+	CampaignID string   `json:"campaign_id"`
+	SentTo     []SentTo `json:"sent_to"`
+}
+
+type SentTo struct {
+	withLinks
+
+	EmailID      string         `json:"email_id"`
+	EmailAddress string         `json:"email_address"`
+	MergeFields  map[string]any `json:"merge_fields"`
+	VIP          bool           `json:"vip"`
+	Status       string         `json:"status"` // one of the SentToStatus* consts
+	OpenCount    int            `json:"open_count"`
+	LastOpen     string         `json:"last_open"`
+	ABSplitGroup string         `json:"absplit_group"`
+	GMTOffset    int            `json:"gmt_offset"`
+	CampaignID   string         `json:"campaign_id"`
+	ListID       string         `json:"list_id"`
+	ListIsActive bool           `json:"list_is_active"`
+}
+
+// Bounced reports whether the email hard or soft bounced.
+func (sent *SentTo) Bounced() bool {
+	return sent.Status == SentToStatusHard || sent.Status == SentToStatusSoft
+}
+
+func (api *API) GetSentTo(ctx context.Context, id string, params *ExtendedQueryParams) (*ListOfSentTo, error) {
+	endpoint := fmt.Sprintf(sentToPath, id)
+	response := new(ListOfSentTo)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) GetSentToMember(ctx context.Context, id, email string, params *BasicQueryParams) (*SentTo, error) {
+	endpoint := fmt.Sprintf(singleSentToPath, id, SubscriberHash(email))
+	response := new(SentTo)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Abuse Reports
+// ------------------------------------------------------------------------------------------------
+
+type ListOfCampaignAbuseReports struct {
+	baseList
+
+	CampaignID   string                `json:"campaign_id"`
+	AbuseReports []CampaignAbuseReport `json:"abuse_reports"`
+}
+
+type CampaignAbuseReport struct {
+	withLinks
+
+	ID           int            `json:"id"`
+	CampaignID   string         `json:"campaign_id"`
+	ListID       string         `json:"list_id"`
+	ListIsActive bool           `json:"list_is_active"`
+	EmailID      string         `json:"email_id"`
+	EmailAddress string         `json:"email_address"`
+	MergeFields  map[string]any `json:"merge_fields"`
+	VIP          bool           `json:"vip"`
+	Date         string         `json:"date"`
+}
+
+func (api *API) GetCampaignAbuseReports(ctx context.Context, id string, params *BasicQueryParams) (*ListOfCampaignAbuseReports, error) {
+	endpoint := fmt.Sprintf(reportAbusePath, id)
+	response := new(ListOfCampaignAbuseReports)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) GetCampaignAbuseReport(ctx context.Context, id string, reportID int, params *BasicQueryParams) (*CampaignAbuseReport, error) {
+	endpoint := fmt.Sprintf(singleReportAbusePath, id, strconv.Itoa(reportID))
+	response := new(CampaignAbuseReport)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Advice
+// ------------------------------------------------------------------------------------------------
+
+type ListOfAdvice struct {
+	baseList
+
+	CampaignID string   `json:"campaign_id"`
+	Advice     []Advice `json:"advice"`
+}
+
+type Advice struct {
+	Type    string `json:"type"` // one of the AdviceType* consts
+	Message string `json:"message"`
+}
+
+func (api *API) GetCampaignAdvice(ctx context.Context, id string, params *BasicQueryParams) (*ListOfAdvice, error) {
+	endpoint := fmt.Sprintf(reportAdvicePath, id)
+	response := new(ListOfAdvice)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Campaign and Report helpers
+// ------------------------------------------------------------------------------------------------
+
+func (campaign *CampaignResponse) GetReport(ctx context.Context, params *BasicQueryParams) (*CampaignReport, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetReport(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetOpenDetails(ctx context.Context, params *ReportActivityQueryParams) (*ListOfOpenDetails, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetOpenDetails(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetClickDetails(ctx context.Context, params *ExtendedQueryParams) (*ListOfClickDetails, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetClickDetails(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetClickDetailMembers(ctx context.Context, linkID string, params *ExtendedQueryParams) (*ListOfClickDetailMembers, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetClickDetailMembers(ctx, campaign.ID, linkID, params)
+}
+
+func (campaign *CampaignResponse) GetEmailActivity(ctx context.Context, params *ReportActivityQueryParams) (*ListOfEmailActivity, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetEmailActivity(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetUnsubscribes(ctx context.Context, params *ExtendedQueryParams) (*ListOfUnsubscribes, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetUnsubscribes(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetSentTo(ctx context.Context, params *ExtendedQueryParams) (*ListOfSentTo, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetSentTo(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetAbuseReports(ctx context.Context, params *BasicQueryParams) (*ListOfCampaignAbuseReports, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetCampaignAbuseReports(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetAdvice(ctx context.Context, params *BasicQueryParams) (*ListOfAdvice, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetCampaignAdvice(ctx, campaign.ID, params)
+}
+
+func (report *CampaignReport) GetOpenDetails(ctx context.Context, params *ReportActivityQueryParams) (*ListOfOpenDetails, error) {
+	if err := report.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return report.api.GetOpenDetails(ctx, report.ID, params)
+}
+
+func (report *CampaignReport) GetClickDetails(ctx context.Context, params *ExtendedQueryParams) (*ListOfClickDetails, error) {
+	if err := report.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return report.api.GetClickDetails(ctx, report.ID, params)
+}
+
+func (report *CampaignReport) GetEmailActivity(ctx context.Context, params *ReportActivityQueryParams) (*ListOfEmailActivity, error) {
+	if err := report.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return report.api.GetEmailActivity(ctx, report.ID, params)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Domain Performance
+// ------------------------------------------------------------------------------------------------
+
+type ListOfDomainPerformance struct {
+	baseList
+
+	CampaignID string              `json:"campaign_id"`
+	TotalSent  int                 `json:"total_sent"`
+	Domains    []DomainPerformance `json:"domains"`
+}
+
+type DomainPerformance struct {
+	Domain     string `json:"domain"`
+	EmailsSent int    `json:"emails_sent"`
+	Bounces    int    `json:"bounces"`
+	Opens      int    `json:"opens"`
+	Clicks     int    `json:"clicks"`
+	Unsubs     int    `json:"unsubs"`
+	Delivered  int    `json:"delivered"`
+
+	// The *Pct fields are the domain's share of all emails, bounces, opens,
+	// clicks and unsubscribes of the campaign.
+	EmailsPct  float64 `json:"emails_pct"`
+	BouncesPct float64 `json:"bounces_pct"`
+	OpensPct   float64 `json:"opens_pct"`
+	ClicksPct  float64 `json:"clicks_pct"`
+	UnsubsPct  float64 `json:"unsubs_pct"`
+
+	// The rates are percentages of the emails sent to the domain for bounces
+	// and of the delivered ones otherwise.
+	BounceRate float64 `json:"-"`
+	OpenRate   float64 `json:"-"`
+	ClickRate  float64 `json:"-"`
+	UnsubRate  float64 `json:"-"`
+}
+
+func (domain *DomainPerformance) calculateRates() {
+	domain.BounceRate = percentage(domain.Bounces, domain.EmailsSent)
+	domain.OpenRate = percentage(domain.Opens, domain.Delivered)
+	domain.ClickRate = percentage(domain.Clicks, domain.Delivered)
+	domain.UnsubRate = percentage(domain.Unsubs, domain.Delivered)
+}
+
+func (api *API) GetDomainPerformance(ctx context.Context, id string, params *BasicQueryParams) (*ListOfDomainPerformance, error) {
+	endpoint := fmt.Sprintf(domainPerformancePath, id)
+	response := new(ListOfDomainPerformance)
+
+	err := api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range response.Domains {
+		response.Domains[i].calculateRates()
+	}
+
+	return response, nil
+}
+
+// AggregateDomainPerformance sums the per-domain numbers of several campaigns
+// and recomputes the shares and rates from the totals. Domains are sorted by
+// emails sent, largest first.
+func AggregateDomainPerformance(reports ...*ListOfDomainPerformance) *ListOfDomainPerformance {
+	aggregate := new(ListOfDomainPerformance)
+	byDomain := make(map[string]*DomainPerformance)
+
+	var bounces, opens, clicks, unsubs int
+	for _, report := range reports {
+		aggregate.TotalSent += report.TotalSent
+		for _, domain := range report.Domains {
+			bounces += domain.Bounces
+			opens += domain.Opens
+			clicks += domain.Clicks
+			unsubs += domain.Unsubs
+
+			total, ok := byDomain[domain.Domain]
+			if !ok {
+				total = &DomainPerformance{Domain: domain.Domain}
+				byDomain[domain.Domain] = total
+			}
+
+			total.EmailsSent += domain.EmailsSent
+			total.Bounces += domain.Bounces
+			total.Opens += domain.Opens
+			total.Clicks += domain.Clicks
+			total.Unsubs += domain.Unsubs
+			total.Delivered += domain.Delivered
+		}
+	}
+
+	for _, domain := range byDomain {
+		domain.EmailsPct = percentage(domain.EmailsSent, aggregate.TotalSent)
+		domain.BouncesPct = percentage(domain.Bounces, bounces)
+		domain.OpensPct = percentage(domain.Opens, opens)
+		domain.ClicksPct = percentage(domain.Clicks, clicks)
+		domain.UnsubsPct = percentage(domain.Unsubs, unsubs)
+		domain.calculateRates()
+		aggregate.Domains = append(aggregate.Domains, *domain)
+	}
+
+	sort.Slice(aggregate.Domains, func(i, j int) bool {
+		a, b := aggregate.Domains[i], aggregate.Domains[j]
+		return a.EmailsSent > b.EmailsSent || (a.EmailsSent == b.EmailsSent && a.Domain < b.Domain)
+	})
+	aggregate.TotalItems = len(aggregate.Domains)
+
+	return aggregate
+}
+
+// GetAggregatedDomainPerformance fetches the domain performance of every
+// campaign and combines it with AggregateDomainPerformance.
+func (api *API) GetAggregatedDomainPerformance(ctx context.Context, campaignIDs ...string) (*ListOfDomainPerformance, error) {
+	reports := make([]*ListOfDomainPerformance, 0, len(campaignIDs))
+	for _, id := range campaignIDs {
+		report, err := api.GetDomainPerformance(ctx, id, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "domain performance of campaign %s", id)
+		}
+		reports = append(reports, report)
+	}
+
+	return AggregateDomainPerformance(reports...), nil
+}
+
+func percentage(part, total int) float64 {
+	return ratio(float64(part), total) * 100
+}
+
+// ------------------------------------------------------------------------------------------------
+// Locations
+// ------------------------------------------------------------------------------------------------
+
+type ListOfOpenLocations struct {
+	baseList
+
+	CampaignID string         `json:"campaign_id"`
+	Locations  []OpenLocation `json:"locations"`
+}
+
+type OpenLocation struct {
+	CountryCode string `json:"country_code"`
+	Region      string `json:"region"`
+	RegionName  string `json:"region_name"`
+	Opens       int    `json:"opens"`
+}
+
+func (api *API) GetOpenLocations(ctx context.Context, id string, params *ExtendedQueryParams) (*ListOfOpenLocations, error) {
+	endpoint := fmt.Sprintf(locationsPath, id)
+	response := new(ListOfOpenLocations)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Eepurl
+// ------------------------------------------------------------------------------------------------
+
+type EepurlActivity struct {
+	withLinks
+
+	Twitter    EepurlTwitter    `json:"twitter"`
+	Clicks     EepurlClicks     `json:"clicks"`
+	Referrers  []EepurlReferrer `json:"referrers"`
+	Eepurl     string           `json:"eepurl"`
+	CampaignID string           `json:"campaign_id"`
+}
+
+type EepurlTwitter struct {
+	Tweets     int            `json:"tweets"`
+	FirstTweet string         `json:"first_tweet"`
+	LastTweet  string         `json:"last_tweet"`
+	Retweets   int            `json:"retweets"`
+	Statuses   []EepurlStatus `json:"statuses"`
+}
+
+type EepurlStatus struct {
+	Status     string `json:"status"`
+	ScreenName string `json:"screen_name"`
+	StatusID   string `json:"status_id"`
+	Datetime   string `json:"datetime"`
+	IsRetweet  bool   `json:"is_retweet"`
+}
+
+type EepurlClicks struct {
+	Clicks     int              `json:"clicks"`
+	FirstClick string           `json:"first_click"`
+	LastClick  string           `json:"last_click"`
+	Locations  []EepurlLocation `json:"locations"`
+}
+
+type EepurlLocation struct {
+	Country string `json:"country"`
+	Region  string `json:"region"`
+}
+
+type EepurlReferrer struct {
+	Referrer   string `json:"referrer"`
+	Clicks     int    `json:"clicks"`
+	FirstClick string `json:"first_click"`
+	LastClick  string `json:"last_click"`
+}
+
+func (api *API) GetEepurlActivity(ctx context.Context, id string, params *BasicQueryParams) (*EepurlActivity, error) {
+	endpoint := fmt.Sprintf(eepurlPath, id)
+	response := new(EepurlActivity)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (campaign *CampaignResponse) GetDomainPerformance(ctx context.Context, params *BasicQueryParams) (*ListOfDomainPerformance, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetDomainPerformance(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetOpenLocations(ctx context.Context, params *ExtendedQueryParams) (*ListOfOpenLocations, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetOpenLocations(ctx, campaign.ID, params)
+}
+
+func (campaign *CampaignResponse) GetEepurlActivity(ctx context.Context, params *BasicQueryParams) (*EepurlActivity, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetEepurlActivity(ctx, campaign.ID, params)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Ecommerce Product Activity
+// ------------------------------------------------------------------------------------------------
+
+const (
+	ProductActivitySortTitle          = "title"
+	ProductActivitySortTotalPurchased = "total_purchased"
+	ProductActivitySortTotalRevenue   = "total_revenue"
+)
+
+type ListOfProductActivity struct {
+	baseList
+
+	CampaignID string            `json:"campaign_id"`
+	Products   []ProductActivity `json:"products"`
+}
+
+type ProductActivity struct {
+	Title                   string `json:"title"`
+	SKU                     string `json:"sku"`
+	ImageURL                string `json:"image_url"`
+	TotalRevenue            Money  `json:"total_revenue"`
+	TotalPurchased          int    `json:"total_purchased"`
+	CurrencyCode            string `json:"currency_code"`
+	RecommendationTotal     int    `json:"recommendation_total"`
+	RecommendationPurchased int    `json:"recommendation_purchased"`
+}
+
+// GetProductActivity returns the revenue and purchases per product. SortField
+// of params takes one of the ProductActivitySort* consts.
+func (api *API) GetProductActivity(ctx context.Context, id string, params *ExtendedQueryParams) (*ListOfProductActivity, error) {
+	endpoint := fmt.Sprintf(productActivityPath, id)
+	response := new(ListOfProductActivity)
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (campaign *CampaignResponse) GetProductActivity(ctx context.Context, params *ExtendedQueryParams) (*ListOfProductActivity, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetProductActivity(ctx, campaign.ID, params)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Sub-Reports
+// ------------------------------------------------------------------------------------------------
+
+// ListOfSubReports holds the reports of a campaign's resends and variate
+// combinations.
+type ListOfSubReports struct {
+	baseList
+
+	CampaignID string           `json:"campaign_id"`
+	Reports    []CampaignReport `json:"reports"`
+}
+
+func (api *API) GetSubReports(ctx context.Context, id string, params *BasicQueryParams) (*ListOfSubReports, error) {
+	endpoint := fmt.Sprintf(subReportsPath, id)
+	response := new(ListOfSubReports)
+
+	err := api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range response.Reports {
+		response.Reports[i].api = api
+	}
+
+	return response, nil
+}
+
+func (campaign *CampaignResponse) GetSubReports(ctx context.Context, params *BasicQueryParams) (*ListOfSubReports, error) {
+	if err := campaign.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return campaign.api.GetSubReports(ctx, campaign.ID, params)
+}
+
+func (report *CampaignReport) GetSubReports(ctx context.Context, params *BasicQueryParams) (*ListOfSubReports, error) {
+	if err := report.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return report.api.GetSubReports(ctx, report.ID, params)
+}
@@ -0,0 +1,223 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateDomainPerformance(t *testing.T) {
+	a := &ListOfDomainPerformance{TotalSent: 100, Domains: []DomainPerformance{
+		{Domain: "gmail.com", EmailsSent: 60, Delivered: 60, Opens: 30, Clicks: 6},
+		{Domain: "yahoo.com", EmailsSent: 40, Bounces: 4, Delivered: 36, Opens: 9},
+	}}
+	b := &ListOfDomainPerformance{TotalSent: 100, Domains: []DomainPerformance{
+		{Domain: "gmail.com", EmailsSent: 100, Delivered: 100, Opens: 50, Clicks: 10, Unsubs: 2},
+	}}
+
+	aggregate := AggregateDomainPerformance(a, b)
+	assert.Equal(t, 200, aggregate.TotalSent)
+	assert.Equal(t, 2, aggregate.TotalItems)
+
+	gmail := aggregate.Domains[0]
+	assert.Equal(t, "gmail.com", gmail.Domain)
+	assert.Equal(t, 160, gmail.EmailsSent)
+	assert.InDelta(t, 80, gmail.EmailsPct, 0.001)
+	assert.InDelta(t, 0, gmail.BouncesPct, 0.001)
+	assert.InDelta(t, 80/89.0*100, gmail.OpensPct, 0.001)
+	assert.InDelta(t, 100, gmail.ClicksPct, 0.001)
+	assert.InDelta(t, 100, gmail.UnsubsPct, 0.001)
+	assert.InDelta(t, 50, gmail.OpenRate, 0.001)
+	assert.InDelta(t, 10, gmail.ClickRate, 0.001)
+	assert.InDelta(t, 1.25, gmail.UnsubRate, 0.001)
+
+	yahoo := aggregate.Domains[1]
+	assert.InDelta(t, 20, yahoo.EmailsPct, 0.001)
+	assert.InDelta(t, 100, yahoo.BouncesPct, 0.001)
+	assert.InDelta(t, 9/89.0*100, yahoo.OpensPct, 0.001)
+	assert.InDelta(t, 0, yahoo.ClicksPct, 0.001)
+	assert.InDelta(t, 10, yahoo.BounceRate, 0.001)
+	assert.InDelta(t, 25, yahoo.OpenRate, 0.001)
+
+	var shares float64
+	for _, domain := range aggregate.Domains {
+		shares += domain.OpensPct
+	}
+	assert.InDelta(t, 100, shares, 0.001)
+}
+
+func TestRollupReports(t *testing.T) {
+	parent := &CampaignReport{ID: "p", EmailsSent: 3, Bounces: ReportBounces{SoftBounces: 1}, Opens: ReportOpens{OpensTotal: 4}}
+	resend := &CampaignReport{ID: "r", EmailsSent: 2, Opens: ReportOpens{OpensTotal: 1}, Unsubscribed: 1}
+
+	sentTo := []SentTo{
+		{EmailID: "a", CampaignID: "p"},
+		{EmailID: "b", CampaignID: "p"},
+		{EmailID: "c", CampaignID: "p", Status: SentToStatusSoft},
+		{EmailID: "b", CampaignID: "r"},
+		{EmailID: "c", CampaignID: "r"},
+	}
+	activity := []EmailActivityRow{
+		{CampaignID: "p", SubscriberHash: "a", Action: EmailActivityOpen},
+		{CampaignID: "p", SubscriberHash: "a", Action: EmailActivityOpen},
+		{CampaignID: "p", SubscriberHash: "a", Action: EmailActivityClick},
+		{CampaignID: "r", SubscriberHash: "a", Action: EmailActivityOpen},
+		{CampaignID: "r", SubscriberHash: "c", Action: EmailActivityOpen},
+	}
+
+	rollup := RollupReports([]*CampaignReport{parent, resend}, sentTo, activity)
+	assert.Equal(t, "p", rollup.CampaignID)
+	assert.Equal(t, 5, rollup.EmailsSent)
+	assert.Equal(t, 5, rollup.OpensTotal)
+	assert.Equal(t, 3, rollup.Recipients)
+	assert.Equal(t, 3, rollup.Delivered)
+	assert.Equal(t, 2, rollup.UniqueOpens)
+	assert.Equal(t, 1, rollup.UniqueClicks)
+	assert.InDelta(t, 2.0/3, rollup.OpenRate, 1e-9)
+
+	estimated := RollupReports([]*CampaignReport{parent, resend}, nil, nil)
+	assert.Equal(t, 5, estimated.Recipients)
+	assert.Equal(t, 4, estimated.Delivered)
+
+	// a variate parent already counts its combinations
+	variate := &CampaignReport{ID: "v", Type: CampaignTypeVariate, EmailsSent: 5, Opens: ReportOpens{OpensTotal: 5}, Unsubscribed: 1}
+	combinationA := &CampaignReport{ID: "va", EmailsSent: 3, Opens: ReportOpens{OpensTotal: 4}}
+	combinationB := &CampaignReport{ID: "vb", EmailsSent: 2, Opens: ReportOpens{OpensTotal: 1}, Unsubscribed: 1}
+
+	combined := RollupReports([]*CampaignReport{variate, combinationA, combinationB}, nil, nil)
+	assert.Equal(t, "v", combined.CampaignID)
+	assert.Len(t, combined.Reports, 3)
+	assert.Equal(t, 5, combined.EmailsSent)
+	assert.Equal(t, 5, combined.OpensTotal)
+	assert.Equal(t, 1, combined.Unsubscribed)
+	assert.Equal(t, 5, combined.Recipients)
+}
+
+func TestGetCampaignReport(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/reports/c1", r.URL.Path)
+		assert.Equal(t, "id,ecommerce", r.URL.Query().Get("fields"))
+		_, _ = fmt.Fprint(w, `{"id":"c1","type":"regular","emails_sent":100,`+
+			`"bounces":{"hard_bounces":2,"soft_bounces":1,"syntax_errors":1},`+
+			`"opens":{"opens_total":40,"unique_opens":30,"open_rate":0.3125},`+
+			`"ab_split":{"a":{"opens":10},"b":{"opens":12}},`+
+			`"ecommerce":{"total_orders":3,"total_spent":"59.97","total_revenue":59.97,"currency_code":"USD"}}`)
+	}
+
+	campaign := &CampaignResponse{ID: "c1", api: testAPI()}
+	report, err := campaign.GetReport(context.Background(), &BasicQueryParams{Fields: []string{"id", "ecommerce"}})
+	fatalIf(t, err)
+
+	assert.Equal(t, 100, report.EmailsSent)
+	assert.Equal(t, 4, report.Bounces.Total())
+	assert.Equal(t, 30, report.Opens.UniqueOpens)
+	assert.Equal(t, 12, report.ABSplit.B.Opens)
+	assert.Equal(t, NewMoney(59, 97), report.Ecommerce.TotalSpent)
+	assert.Equal(t, NewMoney(59, 97), report.Ecommerce.TotalRevenue)
+	assert.NoError(t, report.CanMakeRequest())
+	assert.NotNil(t, report.api)
+}
+
+func TestGetClickDetailMembers(t *testing.T) {
+	hash := SubscriberHash("jane@example.com")
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reports/c1/click-details/l1/members":
+			assert.Equal(t, "50", r.URL.Query().Get("count"))
+			assert.Equal(t, "100", r.URL.Query().Get("offset"))
+			_, _ = fmt.Fprint(w, `{"campaign_id":"c1","members":[`+
+				`{"email_id":"`+hash+`","email_address":"jane@example.com","clicks":3,"url_id":"l1","contact_status":"subscribed"}`+
+				`],"total_items":101}`)
+		case "/reports/c1/click-details/l1/members/" + hash:
+			_, _ = fmt.Fprint(w, `{"email_id":"`+hash+`","clicks":3}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+
+	api := testAPI()
+	members, err := api.GetClickDetailMembers(context.Background(), "c1", "l1", &ExtendedQueryParams{Count: 50, Offset: 100})
+	fatalIf(t, err)
+
+	assert.Equal(t, 101, members.TotalItems)
+	assert.Len(t, members.Members, 1)
+	assert.Equal(t, 3, members.Members[0].Clicks)
+	assert.Equal(t, "subscribed", members.Members[0].ContactStatus)
+
+	member, err := api.GetClickDetailMember(context.Background(), "c1", "l1", "Jane@Example.com", nil)
+	fatalIf(t, err)
+	assert.Equal(t, hash, member.EmailID)
+}
+
+func TestGetEmailActivity(t *testing.T) {
+	hash := SubscriberHash("jane@example.com")
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2024-03-01T00:00:00Z", r.URL.Query().Get("since"))
+		switch r.URL.Path {
+		case "/reports/c1/email-activity":
+			assert.Equal(t, "10", r.URL.Query().Get("count"))
+			_, _ = fmt.Fprint(w, `{"campaign_id":"c1","emails":[{"email_id":"`+hash+`","email_address":"jane@example.com","activity":[`+
+				`{"action":"open","timestamp":"2024-03-02T10:00:00+00:00","ip":"10.0.0.1"},`+
+				`{"action":"bounce","type":"soft","timestamp":"2024-03-02T11:00:00+00:00"}`+
+				`]}],"total_items":1}`)
+		case "/reports/c1/email-activity/" + hash:
+			_, _ = fmt.Fprint(w, `{"email_id":"`+hash+`","activity":[{"action":"click","url":"https://example.com"}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+
+	report := &CampaignReport{ID: "c1", api: testAPI()}
+	params := &ReportActivityQueryParams{Since: "2024-03-01T00:00:00Z"}
+	params.Count = 10
+
+	activity, err := report.GetEmailActivity(context.Background(), params)
+	fatalIf(t, err)
+	assert.Len(t, activity.Emails, 1)
+	events := activity.Emails[0].Activity
+	assert.Equal(t, EmailActivityOpen, events[0].Action)
+	assert.Equal(t, "10.0.0.1", events[0].IP)
+	assert.Equal(t, "soft", events[1].Type)
+
+	member, err := report.api.GetMemberEmailActivity(context.Background(), "c1", "jane@example.com", params)
+	fatalIf(t, err)
+	assert.Equal(t, "https://example.com", member.Activity[0].URL)
+}
+
+func TestRollupCampaignReportPagesSentTo(t *testing.T) {
+	var offsets []string
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reports/p":
+			_, _ = fmt.Fprint(w, `{"id":"p","type":"regular","emails_sent":3}`)
+		case "/reports/p/sub-reports":
+			_, _ = fmt.Fprint(w, `{"reports":[],"total_items":0}`)
+		case "/reports/p/sent-to":
+			assert.Equal(t, "1000", r.URL.Query().Get("count"))
+			offset := r.URL.Query().Get("offset")
+			offsets = append(offsets, offset)
+			if offset == "0" {
+				_, _ = fmt.Fprint(w, `{"sent_to":[{"email_id":"a"},{"email_id":"b","status":"hard"}],"total_items":3}`)
+			} else {
+				_, _ = fmt.Fprint(w, `{"sent_to":[{"email_id":"c","status":"sent"}],"total_items":3}`)
+			}
+		case "/reports/p/email-activity":
+			_, _ = fmt.Fprint(w, `{"emails":[{"email_id":"a","activity":[{"action":"open","timestamp":"2024-03-02T10:00:00+00:00"}]}],"total_items":1}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}
+
+	rollup, err := testAPI().RollupCampaignReport(context.Background(), "p")
+	fatalIf(t, err)
+
+	assert.Equal(t, []string{"0", "2"}, offsets)
+	assert.Equal(t, 3, rollup.Recipients)
+	assert.Equal(t, 2, rollup.Delivered)
+	assert.Equal(t, 1, rollup.UniqueOpens)
+	assert.InDelta(t, 0.5, rollup.OpenRate, 1e-9)
+}
@@ -0,0 +1,809 @@
+package gochimp3
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	ConditionMatchAny = "any"
+	ConditionMatchAll = "all"
+
+	ConditionTypeAim                 = "Aim"
+	ConditionTypeAutomation          = "Automation"
+	ConditionTypeCampaignPoll        = "CampaignPoll"
+	ConditionTypeConversation        = "Conversation"
+	ConditionTypeDate                = "Date"
+	ConditionTypeEmailClient         = "EmailClient"
+	ConditionTypeLanguage            = "Language"
+	ConditionTypeMandrill            = "Mandrill"
+	ConditionTypeMemberRating        = "MemberRating"
+	ConditionTypeSignupSource        = "SignupSource"
+	ConditionTypeSurveyMonkey        = "SurveyMonkey"
+	ConditionTypeVIP                 = "VIP"
+	ConditionTypeInterests           = "Interests"
+	ConditionTypeEcommCategory       = "EcommCategory"
+	ConditionTypeEcommNumber         = "EcommNumber"
+	ConditionTypeEcommPurchased      = "EcommPurchased"
+	ConditionTypeEcommSpent          = "EcommSpent"
+	ConditionTypeEcommStore          = "EcommStore"
+	ConditionTypeGoalActivity        = "GoalActivity"
+	ConditionTypeGoalTimestamp       = "GoalTimestamp"
+	ConditionTypeFuzzySegment        = "FuzzySegment"
+	ConditionTypeStaticSegment       = "StaticSegment"
+	ConditionTypeIPGeoCountryState   = "IPGeoCountryState"
+	ConditionTypeIPGeoIn             = "IPGeoIn"
+	ConditionTypeIPGeoInZip          = "IPGeoInZip"
+	ConditionTypeIPGeoUnknown        = "IPGeoUnknown"
+	ConditionTypeIPGeoZip            = "IPGeoZip"
+	ConditionTypeSocialAge           = "SocialAge"
+	ConditionTypeSocialGender        = "SocialGender"
+	ConditionTypeSocialInfluence     = "SocialInfluence"
+	ConditionTypeSocialNetworkMember = "SocialNetworkMember"
+	ConditionTypeSocialNetworkFollow = "SocialNetworkFollow"
+	ConditionTypeAddressMerge        = "AddressMerge"
+	ConditionTypeZipMerge            = "ZipMerge"
+	ConditionTypeBirthdayMerge       = "BirthdayMerge"
+	ConditionTypeDateMerge           = "DateMerge"
+	ConditionTypeTextMerge           = "TextMerge"
+	ConditionTypeSelectMerge         = "SelectMerge"
+	ConditionTypeEmailAddress        = "EmailAddress"
+	ConditionTypePredictedGender     = "PredictedGender"
+	ConditionTypePredictedAge        = "PredictedAge"
+
+	ConditionOpContains            = "interestcontains"
+	ConditionOpInterestContainsAll = "interestcontainsall"
+	ConditionOpInterestNotContains = "interestnotcontains"
+
+	ConditionOpIs              = "is"
+	ConditionOpNot             = "not"
+	ConditionOpGreater         = "greater"
+	ConditionOpLess            = "less"
+	ConditionOpBlank           = "blank"
+	ConditionOpBlankNot        = "blank_not"
+	ConditionOpTextContains    = "contains"
+	ConditionOpTextNotContains = "notcontain"
+	ConditionOpStarts          = "starts"
+	ConditionOpEnds            = "ends"
+	ConditionOpWithin          = "within"
+	ConditionOpNotWithin       = "notwithin"
+	ConditionOpMember          = "member"
+	ConditionOpNotMember       = "notmember"
+
+	ConditionOpOpen    = "open"
+	ConditionOpClick   = "click"
+	ConditionOpSent    = "sent"
+	ConditionOpNoOpen  = "noopen"
+	ConditionOpNoClick = "noclick"
+	ConditionOpNoSent  = "nosent"
+
+	ConditionOpStarted      = "started"
+	ConditionOpCompleted    = "completed"
+	ConditionOpNotStarted   = "not_started"
+	ConditionOpNotCompleted = "not_completed"
+
+	ConditionOpClientIs  = "client_is"
+	ConditionOpClientNot = "client_not"
+	ConditionOpSourceIs  = "source_is"
+	ConditionOpSourceNot = "source_not"
+
+	ConditionOpGoalNot         = "goal_not"
+	ConditionOpGoalNotContains = "goal_notcontain"
+
+	ConditionOpFuzzyIs   = "fuzzy_is"
+	ConditionOpFuzzyNot  = "fuzzy_not"
+	ConditionOpStaticIs  = "static_is"
+	ConditionOpStaticNot = "static_not"
+
+	ConditionOpIPGeoCountry    = "ipgeocountry"
+	ConditionOpIPGeoNotCountry = "ipgeonotcountry"
+	ConditionOpIPGeoState      = "ipgeostate"
+	ConditionOpIPGeoNotState   = "ipgeonotstate"
+	ConditionOpIPGeoIn         = "ipgeoin"
+	ConditionOpIPGeoNotIn      = "ipgeonotin"
+	ConditionOpIPGeoInZip      = "ipgeoinzip"
+	ConditionOpIPGeoUnknown    = "ipgeounknown"
+	ConditionOpIPGeoIsZip      = "ipgeoiszip"
+	ConditionOpIPGeoNotZip     = "ipgeonotzip"
+
+	ConditionOpFollow    = "follow"
+	ConditionOpNotFollow = "notfollow"
+	ConditionOpGeoIn     = "geoin"
+)
+
+const (
+	conditionValueNone = iota
+	conditionValueString
+	conditionValueNumber
+	conditionValueStrings
+)
+
+// conditionSpec describes what the API accepts for a condition type.
+type conditionSpec struct {
+	fields      []string // allowed fields, any non-empty field when nil
+	fieldPrefix string
+	ops         []string
+	value       int
+	extra       int
+}
+
+var conditionSpecs = map[string]conditionSpec{
+	ConditionTypeAim: {
+		fields: []string{"aim"},
+		ops:    []string{ConditionOpOpen, ConditionOpClick, ConditionOpSent, ConditionOpNoOpen, ConditionOpNoClick, ConditionOpNoSent},
+		value:  conditionValueString,
+	},
+	ConditionTypeAutomation: {
+		fields: []string{"automation"},
+		ops:    []string{ConditionOpStarted, ConditionOpCompleted, ConditionOpNotStarted, ConditionOpNotCompleted},
+		value:  conditionValueString,
+	},
+	ConditionTypeCampaignPoll: {
+		fields: []string{"poll"},
+		ops:    []string{ConditionOpMember, ConditionOpNotMember},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeConversation: {
+		fields: []string{"conversation"},
+		ops:    []string{ConditionOpMember, ConditionOpNotMember},
+		value:  conditionValueString,
+	},
+	ConditionTypeDate: {
+		fields: []string{"timestamp_opt", "info_changed", "ecomm_date"},
+		ops:    []string{ConditionOpGreater, ConditionOpLess, ConditionOpIs, ConditionOpNot, ConditionOpBlank, ConditionOpBlankNot, ConditionOpWithin, ConditionOpNotWithin},
+		value:  conditionValueString,
+		extra:  conditionValueString,
+	},
+	ConditionTypeEmailClient: {
+		fields: []string{"email_client"},
+		ops:    []string{ConditionOpClientIs, ConditionOpClientNot},
+		value:  conditionValueString,
+	},
+	ConditionTypeLanguage: {
+		fields: []string{"language"},
+		ops:    []string{ConditionOpIs, ConditionOpNot},
+		value:  conditionValueString,
+	},
+	ConditionTypeMandrill: {
+		fields: []string{"mandrill_sent", "mandrill_open", "mandrill_click"},
+		ops:    []string{ConditionOpGreater, ConditionOpLess, ConditionOpIs},
+		value:  conditionValueString,
+		extra:  conditionValueString,
+	},
+	ConditionTypeMemberRating: {
+		fields: []string{"rating"},
+		ops:    []string{ConditionOpIs, ConditionOpNot, ConditionOpGreater, ConditionOpLess},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeSignupSource: {
+		fields: []string{"source"},
+		ops:    []string{ConditionOpSourceIs, ConditionOpSourceNot},
+		value:  conditionValueString,
+	},
+	ConditionTypeSurveyMonkey: {
+		fields: []string{"survey_monkey"},
+		ops:    []string{ConditionOpStarted, ConditionOpCompleted, ConditionOpNotStarted, ConditionOpNotCompleted},
+		value:  conditionValueString,
+	},
+	ConditionTypeVIP: {
+		fields: []string{"gmonkey"},
+		ops:    []string{ConditionOpMember, ConditionOpNotMember},
+	},
+	ConditionTypeInterests: {
+		fieldPrefix: "interests-",
+		ops:         []string{ConditionOpContains, ConditionOpInterestContainsAll, ConditionOpInterestNotContains},
+		value:       conditionValueStrings,
+	},
+	ConditionTypeEcommCategory: {
+		fields: []string{"ecomm_cat", "ecomm_prod"},
+		ops:    []string{ConditionOpIs, ConditionOpNot, ConditionOpTextContains, ConditionOpTextNotContains, ConditionOpStarts, ConditionOpEnds},
+		value:  conditionValueString,
+	},
+	ConditionTypeEcommNumber: {
+		fields: []string{"ecomm_avg_ord", "ecomm_orders", "ecomm_prod_all", "ecomm_avg_spent"},
+		ops:    []string{ConditionOpIs, ConditionOpNot, ConditionOpGreater, ConditionOpLess},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeEcommPurchased: {
+		fields: []string{"ecomm_purchased"},
+		ops:    []string{ConditionOpMember, ConditionOpNotMember},
+	},
+	ConditionTypeEcommSpent: {
+		fields: []string{"ecomm_spent_one", "ecomm_spent_all"},
+		ops:    []string{ConditionOpGreater, ConditionOpLess},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeEcommStore: {
+		fields: []string{"ecomm_store"},
+		ops:    []string{ConditionOpIs, ConditionOpNot},
+		value:  conditionValueString,
+	},
+	ConditionTypeGoalActivity: {
+		fields: []string{"goal"},
+		ops:    []string{ConditionOpIs, ConditionOpGoalNot, ConditionOpTextContains, ConditionOpGoalNotContains, ConditionOpStarts, ConditionOpEnds},
+		value:  conditionValueString,
+	},
+	ConditionTypeGoalTimestamp: {
+		fields: []string{"goal_last_visited"},
+		ops:    []string{ConditionOpGreater, ConditionOpLess, ConditionOpIs},
+		value:  conditionValueString,
+	},
+	ConditionTypeFuzzySegment: {
+		fields: []string{"fuzzy_segment"},
+		ops:    []string{ConditionOpFuzzyIs, ConditionOpFuzzyNot},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeStaticSegment: {
+		fields: []string{"static_segment"},
+		ops:    []string{ConditionOpStaticIs, ConditionOpStaticNot},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeIPGeoCountryState: {
+		fields: []string{"ipgeo"},
+		ops:    []string{ConditionOpIPGeoCountry, ConditionOpIPGeoNotCountry, ConditionOpIPGeoState, ConditionOpIPGeoNotState},
+		value:  conditionValueString,
+	},
+	ConditionTypeIPGeoIn: {
+		fields: []string{"ipgeo"},
+		ops:    []string{ConditionOpIPGeoIn, ConditionOpIPGeoNotIn},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeIPGeoInZip: {
+		fields: []string{"ipgeo"},
+		ops:    []string{ConditionOpIPGeoInZip},
+		value:  conditionValueNumber,
+		extra:  conditionValueNumber,
+	},
+	ConditionTypeIPGeoUnknown: {
+		fields: []string{"ipgeo"},
+		ops:    []string{ConditionOpIPGeoUnknown},
+	},
+	ConditionTypeIPGeoZip: {
+		fields: []string{"ipgeo"},
+		ops:    []string{ConditionOpIPGeoIsZip, ConditionOpIPGeoNotZip},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeSocialAge: {
+		fields: []string{"social_age"},
+		ops:    []string{ConditionOpIs, ConditionOpNot, ConditionOpGreater, ConditionOpLess},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeSocialGender: {
+		fields: []string{"social_gender"},
+		ops:    []string{ConditionOpIs, ConditionOpNot},
+		value:  conditionValueString,
+	},
+	ConditionTypeSocialInfluence: {
+		fields: []string{"social_influence"},
+		ops:    []string{ConditionOpIs, ConditionOpNot, ConditionOpGreater, ConditionOpLess},
+		value:  conditionValueNumber,
+	},
+	ConditionTypeSocialNetworkMember: {
+		fields: []string{"social_network"},
+		ops:    []string{ConditionOpMember, ConditionOpNotMember},
+		value:  conditionValueString,
+	},
+	ConditionTypeSocialNetworkFollow: {
+		fields: []string{"social_network"},
+		ops:    []string{ConditionOpFollow, ConditionOpNotFollow},
+		value:  conditionValueString,
+	},
+	ConditionTypeAddressMerge: {
+		ops:   []string{ConditionOpTextContains, ConditionOpTextNotContains, ConditionOpBlank, ConditionOpBlankNot},
+		value: conditionValueString,
+	},
+	ConditionTypeZipMerge: {
+		ops:   []string{ConditionOpGeoIn},
+		value: conditionValueString,
+		extra: conditionValueString,
+	},
+	ConditionTypeBirthdayMerge: {
+		ops:   []string{ConditionOpIs, ConditionOpNot, ConditionOpBlank, ConditionOpBlankNot},
+		value: conditionValueString,
+	},
+	ConditionTypeDateMerge: {
+		ops:   []string{ConditionOpIs, ConditionOpNot, ConditionOpLess, ConditionOpGreater, ConditionOpBlank, ConditionOpBlankNot},
+		value: conditionValueString,
+	},
+	ConditionTypeTextMerge: {
+		ops: []string{
+			ConditionOpIs, ConditionOpNot, ConditionOpTextContains, ConditionOpTextNotContains, ConditionOpStarts,
+			ConditionOpEnds, ConditionOpGreater, ConditionOpLess, ConditionOpBlank, ConditionOpBlankNot,
+		},
+		value: conditionValueString,
+	},
+	ConditionTypeSelectMerge: {
+		ops:   []string{ConditionOpIs, ConditionOpNot, ConditionOpBlank, ConditionOpBlankNot},
+		value: conditionValueString,
+	},
+	ConditionTypeEmailAddress: {
+		fields: []string{"merge0", "EMAIL"},
+		ops: []string{
+			ConditionOpIs, ConditionOpNot, ConditionOpTextContains, ConditionOpTextNotContains,
+			ConditionOpStarts, ConditionOpEnds, ConditionOpGreater, ConditionOpLess,
+		},
+		value: conditionValueString,
+	},
+	ConditionTypePredictedGender: {
+		fields: []string{"predicted_gender"},
+		ops:    []string{ConditionOpIs, ConditionOpNot},
+		value:  conditionValueString,
+	},
+	ConditionTypePredictedAge: {
+		fields: []string{"predicted_age_range"},
+		ops:    []string{ConditionOpIs},
+		value:  conditionValueString,
+	},
+}
+
+// ------------------------------------------------------------------------------------------------
+// Validation
+// ------------------------------------------------------------------------------------------------
+
+// Validate checks the condition against the fields, ops and value types the
+// API documents for its condition type. Conditions without a type are only
+// checked for a field and op.
+func (cond *SegmentConditional) Validate() error {
+	if cond.Field == "" {
+		return errors.New("condition has no field")
+	}
+
+	if cond.OP == "" {
+		return errors.New("condition has no op")
+	}
+
+	if cond.ConditionType == "" {
+		return nil
+	}
+
+	spec, ok := conditionSpecs[cond.ConditionType]
+	if !ok {
+		return errors.Errorf("unknown condition type %q", cond.ConditionType)
+	}
+
+	switch {
+	case spec.fieldPrefix != "":
+		if !strings.HasPrefix(cond.Field, spec.fieldPrefix) || cond.Field == spec.fieldPrefix {
+			return errors.Errorf("%s condition field must look like %s<id>, got %q", cond.ConditionType, spec.fieldPrefix, cond.Field)
+		}
+	case spec.fields != nil:
+		if !containsString(spec.fields, cond.Field) {
+			return errors.Errorf("%s condition field must be one of %s, got %q", cond.ConditionType, strings.Join(spec.fields, ", "), cond.Field)
+		}
+	}
+
+	if !containsString(spec.ops, cond.OP) {
+		return errors.Errorf("%s condition op must be one of %s, got %q", cond.ConditionType, strings.Join(spec.ops, ", "), cond.OP)
+	}
+
+	value := spec.value
+	if cond.OP == ConditionOpBlank || cond.OP == ConditionOpBlankNot {
+		value = conditionValueNone
+	}
+
+	if err := checkConditionValue(value, cond.Value); err != nil {
+		return errors.Wrapf(err, "%s condition value", cond.ConditionType)
+	}
+
+	if spec.extra != conditionValueNone && cond.Extra != nil {
+		if err := checkConditionValue(spec.extra, cond.Extra); err != nil {
+			return errors.Wrapf(err, "%s condition extra", cond.ConditionType)
+		}
+	}
+
+	switch cond.ConditionType {
+	case ConditionTypeIPGeoIn:
+		if cond.Addr == "" && (cond.Lat == "" || cond.Lng == "") {
+			return errors.New("IPGeoIn condition needs an address or a latitude and longitude")
+		}
+	case ConditionTypeIPGeoInZip, ConditionTypeZipMerge:
+		if cond.Extra == nil {
+			return errors.Errorf("%s condition needs a zip code in extra", cond.ConditionType)
+		}
+	}
+
+	return nil
+}
+
+func checkConditionValue(kind int, value any) error {
+	switch kind {
+	case conditionValueNone:
+		if value != nil && value != "" {
+			return errors.Errorf("must be empty, got %v", value)
+		}
+	case conditionValueString:
+		s, ok := value.(string)
+		if !ok {
+			return errors.Errorf("must be a string, got %T", value)
+		}
+		if s == "" {
+			return errors.New("must not be empty")
+		}
+	case conditionValueNumber:
+		if value == nil {
+			return errors.New("must be a number")
+		}
+		switch reflect.TypeOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			return errors.Errorf("must be a number, got %T", value)
+		}
+	case conditionValueStrings:
+		// decoded JSON holds the strings as []any
+		var n int
+		switch values := value.(type) {
+		case []string:
+			n = len(values)
+		case []any:
+			for _, v := range values {
+				if _, ok := v.(string); !ok {
+					return errors.Errorf("must be a list of strings, got a %T in the list", v)
+				}
+			}
+			n = len(values)
+		default:
+			return errors.Errorf("must be a list of strings, got %T", value)
+		}
+		if n == 0 {
+			return errors.New("must not be empty")
+		}
+	}
+
+	return nil
+}
+
+func validateConditionMatch(match string) error {
+	if match != ConditionMatchAny && match != ConditionMatchAll {
+		return errors.Errorf("match must be %q or %q, got %q", ConditionMatchAny, ConditionMatchAll, match)
+	}
+
+	return nil
+}
+
+func validateConditions(match string, conditions []SegmentConditional) error {
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	if err := validateConditionMatch(match); err != nil {
+		return err
+	}
+
+	for i := range conditions {
+		if err := conditions[i].Validate(); err != nil {
+			return errors.Wrapf(err, "condition %d", i)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the match and every condition before they are sent.
+func (opts *SegmentOptions) Validate() error {
+	return validateConditions(opts.Match, opts.Conditions)
+}
+
+// Validate checks the conditions when they are one of the typed condition
+// slices. Other payloads are passed on to the API unchecked.
+func (opts *CampaignCreationSegmentOptions) Validate() error {
+	switch conditions := opts.Conditions.(type) {
+	case []SegmentConditional:
+		return validateConditions(opts.Match, conditions)
+	case []InterestsCondition:
+		converted := make([]SegmentConditional, 0, len(conditions))
+		for _, condition := range conditions {
+			converted = append(converted, condition.Conditional())
+		}
+		return validateConditions(opts.Match, converted)
+	}
+
+	return nil
+}
+
+// Conditional converts the condition into a SegmentConditional.
+func (cond InterestsCondition) Conditional() SegmentConditional {
+	return SegmentConditional{
+		ConditionType: cond.ConditionType,
+		Field:         cond.Field,
+		OP:            cond.Op,
+		Value:         cond.Value,
+	}
+}
+
+// Validate checks the interests condition before it is sent.
+func (cond InterestsCondition) Validate() error {
+	conditional := cond.Conditional()
+	return conditional.Validate()
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ------------------------------------------------------------------------------------------------
+// Builder
+// ------------------------------------------------------------------------------------------------
+
+// SegmentBuilder collects conditions and validates them as a whole, for use
+// in saved segments and in campaign recipients alike.
+type SegmentBuilder struct {
+	match      string
+	conditions []SegmentConditional
+}
+
+// NewSegmentBuilder starts a segment matching one of the ConditionMatch* consts.
+func NewSegmentBuilder(match string) *SegmentBuilder {
+	return &SegmentBuilder{match: match}
+}
+
+// Where adds conditions to the segment.
+func (b *SegmentBuilder) Where(conditions ...SegmentConditional) *SegmentBuilder {
+	b.conditions = append(b.conditions, conditions...)
+	return b
+}
+
+// Validate checks every condition added so far.
+func (b *SegmentBuilder) Validate() error {
+	if err := validateConditionMatch(b.match); err != nil {
+		return err
+	}
+
+	return validateConditions(b.match, b.conditions)
+}
+
+// SegmentOptions returns the conditions for a SegmentRequest.
+func (b *SegmentBuilder) SegmentOptions() (*SegmentOptions, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &SegmentOptions{
+		Match:      b.match,
+		Conditions: append([]SegmentConditional(nil), b.conditions...),
+	}, nil
+}
+
+// CampaignSegmentOptions returns the conditions for campaign recipients.
+func (b *SegmentBuilder) CampaignSegmentOptions() (*CampaignCreationSegmentOptions, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &CampaignCreationSegmentOptions{
+		Match:      b.match,
+		Conditions: append([]SegmentConditional(nil), b.conditions...),
+	}, nil
+}
+
+// ConditionTypes returns every condition type that can be validated locally.
+func ConditionTypes() []string {
+	types := make([]string, 0, len(conditionSpecs))
+	for conditionType := range conditionSpecs {
+		types = append(types, conditionType)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// ConditionOps returns the ops valid for the condition type.
+func ConditionOps(conditionType string) []string {
+	return append([]string(nil), conditionSpecs[conditionType].ops...)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Conditions
+// ------------------------------------------------------------------------------------------------
+
+// NewAimCondition segments on campaign activity, campaignID may be "any".
+func NewAimCondition(op, campaignID string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeAim, Field: "aim", OP: op, Value: campaignID}
+}
+
+func NewAutomationCondition(op, workflowID string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeAutomation, Field: "automation", OP: op, Value: workflowID}
+}
+
+func NewCampaignPollCondition(op string, pollID int) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeCampaignPoll, Field: "poll", OP: op, Value: pollID}
+}
+
+func NewConversationCondition(op, campaignID string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeConversation, Field: "conversation", OP: op, Value: campaignID}
+}
+
+// NewDateCondition segments on timestamp_opt, info_changed or ecomm_date.
+// Value is the kind of date (e.g. "date" or "campaign") and extra the date
+// or campaign ID it refers to.
+func NewDateCondition(field, op, value, extra string) SegmentConditional {
+	cond := SegmentConditional{ConditionType: ConditionTypeDate, Field: field, OP: op, Value: value}
+	if extra != "" {
+		cond.Extra = extra
+	}
+	return cond
+}
+
+func NewEmailClientCondition(op, client string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeEmailClient, Field: "email_client", OP: op, Value: client}
+}
+
+func NewLanguageCondition(op, language string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeLanguage, Field: "language", OP: op, Value: language}
+}
+
+// NewMandrillCondition segments on mandrill_sent, mandrill_open or mandrill_click.
+func NewMandrillCondition(field, op, value, extra string) SegmentConditional {
+	cond := SegmentConditional{ConditionType: ConditionTypeMandrill, Field: field, OP: op, Value: value}
+	if extra != "" {
+		cond.Extra = extra
+	}
+	return cond
+}
+
+func NewMemberRatingCondition(op string, rating int) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeMemberRating, Field: "rating", OP: op, Value: rating}
+}
+
+func NewSignupSourceCondition(op, source string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeSignupSource, Field: "source", OP: op, Value: source}
+}
+
+func NewSurveyMonkeyCondition(op, surveyID string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeSurveyMonkey, Field: "survey_monkey", OP: op, Value: surveyID}
+}
+
+func NewVIPCondition(op string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeVIP, Field: "gmonkey", OP: op}
+}
+
+func NewInterestsCondition(categoryID, op string, interestIDs ...string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeInterests, Field: "interests-" + categoryID, OP: op, Value: interestIDs}
+}
+
+// NewEcommCategoryCondition segments on ecomm_cat or ecomm_prod.
+func NewEcommCategoryCondition(field, op, value string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeEcommCategory, Field: field, OP: op, Value: value}
+}
+
+// NewEcommNumberCondition segments on ecomm_avg_ord, ecomm_orders,
+// ecomm_prod_all or ecomm_avg_spent.
+func NewEcommNumberCondition(field, op string, value float64) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeEcommNumber, Field: field, OP: op, Value: value}
+}
+
+func NewEcommPurchasedCondition(op string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeEcommPurchased, Field: "ecomm_purchased", OP: op}
+}
+
+// NewEcommSpentCondition segments on ecomm_spent_one or ecomm_spent_all.
+func NewEcommSpentCondition(field, op string, value float64) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeEcommSpent, Field: field, OP: op, Value: value}
+}
+
+func NewEcommStoreCondition(op, storeID string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeEcommStore, Field: "ecomm_store", OP: op, Value: storeID}
+}
+
+func NewGoalActivityCondition(op, value string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeGoalActivity, Field: "goal", OP: op, Value: value}
+}
+
+func NewGoalTimestampCondition(op, value string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeGoalTimestamp, Field: "goal_last_visited", OP: op, Value: value}
+}
+
+func NewFuzzySegmentCondition(op string, segmentID int) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeFuzzySegment, Field: "fuzzy_segment", OP: op, Value: segmentID}
+}
+
+func NewStaticSegmentCondition(op string, segmentID int) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeStaticSegment, Field: "static_segment", OP: op, Value: segmentID}
+}
+
+func NewIPGeoCountryStateCondition(op, value string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeIPGeoCountryState, Field: "ipgeo", OP: op, Value: value}
+}
+
+// NewIPGeoInCondition segments on members within distance miles of addr, or
+// of lat and lng when addr is empty.
+func NewIPGeoInCondition(op string, distance int, addr, lat, lng string) SegmentConditional {
+	return SegmentConditional{
+		ConditionType: ConditionTypeIPGeoIn,
+		Field:         "ipgeo",
+		OP:            op,
+		Value:         distance,
+		Addr:          addr,
+		Lat:           lat,
+		Lng:           lng,
+	}
+}
+
+func NewIPGeoInZipCondition(distance, zip int) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeIPGeoInZip, Field: "ipgeo", OP: ConditionOpIPGeoInZip, Value: distance, Extra: zip}
+}
+
+func NewIPGeoUnknownCondition() SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeIPGeoUnknown, Field: "ipgeo", OP: ConditionOpIPGeoUnknown}
+}
+
+func NewIPGeoZipCondition(op string, zip int) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeIPGeoZip, Field: "ipgeo", OP: op, Value: zip}
+}
+
+func NewSocialAgeCondition(op string, age int) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeSocialAge, Field: "social_age", OP: op, Value: age}
+}
+
+func NewSocialGenderCondition(op, gender string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeSocialGender, Field: "social_gender", OP: op, Value: gender}
+}
+
+func NewSocialInfluenceCondition(op string, score float64) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeSocialInfluence, Field: "social_influence", OP: op, Value: score}
+}
+
+func NewSocialNetworkMemberCondition(op, network string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeSocialNetworkMember, Field: "social_network", OP: op, Value: network}
+}
+
+func NewSocialNetworkFollowCondition(op, network string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeSocialNetworkFollow, Field: "social_network", OP: op, Value: network}
+}
+
+// mergeCondition builds a merge field condition, leaving the value out for
+// the blank ops.
+func mergeCondition(conditionType, field, op, value string) SegmentConditional {
+	cond := SegmentConditional{ConditionType: conditionType, Field: field, OP: op}
+	if op != ConditionOpBlank && op != ConditionOpBlankNot {
+		cond.Value = value
+	}
+	return cond
+}
+
+func NewAddressMergeCondition(field, op, value string) SegmentConditional {
+	return mergeCondition(ConditionTypeAddressMerge, field, op, value)
+}
+
+// NewZipMergeCondition segments on members within distance miles of zip.
+func NewZipMergeCondition(field, distance, zip string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeZipMerge, Field: field, OP: ConditionOpGeoIn, Value: distance, Extra: zip}
+}
+
+func NewBirthdayMergeCondition(field, op, value string) SegmentConditional {
+	return mergeCondition(ConditionTypeBirthdayMerge, field, op, value)
+}
+
+func NewDateMergeCondition(field, op, value string) SegmentConditional {
+	return mergeCondition(ConditionTypeDateMerge, field, op, value)
+}
+
+func NewTextMergeCondition(field, op, value string) SegmentConditional {
+	return mergeCondition(ConditionTypeTextMerge, field, op, value)
+}
+
+func NewSelectMergeCondition(field, op, value string) SegmentConditional {
+	return mergeCondition(ConditionTypeSelectMerge, field, op, value)
+}
+
+func NewEmailAddressCondition(op, value string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypeEmailAddress, Field: "EMAIL", OP: op, Value: value}
+}
+
+func NewPredictedGenderCondition(op, gender string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypePredictedGender, Field: "predicted_gender", OP: op, Value: gender}
+}
+
+func NewPredictedAgeCondition(ageRange string) SegmentConditional {
+	return SegmentConditional{ConditionType: ConditionTypePredictedAge, Field: "predicted_age_range", OP: ConditionOpIs, Value: ageRange}
+}
@@ -0,0 +1,122 @@
+package gochimp3
+
+import (
+	"context"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegmentBuilderValid(t *testing.T) {
+	builder := NewSegmentBuilder(ConditionMatchAll).Where(
+		NewAimCondition(ConditionOpOpen, "any"),
+		NewMemberRatingCondition(ConditionOpGreater, 3),
+		NewInterestsCondition("abc123", ConditionOpContains, "i1", "i2"),
+		NewTextMergeCondition("FNAME", ConditionOpBlankNot, ""),
+		NewVIPCondition(ConditionOpMember),
+		NewIPGeoInCondition(ConditionOpIPGeoIn, 25, "", "40.7", "-74.0"),
+		NewStaticSegmentCondition(ConditionOpStaticIs, 42),
+	)
+
+	opts, err := builder.SegmentOptions()
+	fatalIf(t, err)
+	assert.Equal(t, ConditionMatchAll, opts.Match)
+	assert.Len(t, opts.Conditions, 7)
+
+	campaignOpts, err := builder.CampaignSegmentOptions()
+	fatalIf(t, err)
+	assert.Nil(t, campaignOpts.Validate())
+
+	data, err := json.Marshal(opts.Conditions[4])
+	fatalIf(t, err)
+	assert.JSONEq(t, `{"condition_type":"VIP","field":"gmonkey","op":"member","value":null}`, string(data))
+}
+
+func TestSegmentBuilderInvalid(t *testing.T) {
+	tests := map[string]SegmentConditional{
+		"op not valid for type":  NewAimCondition(ConditionOpIs, "any"),
+		"number value as string": {ConditionType: ConditionTypeMemberRating, Field: "rating", OP: ConditionOpIs, Value: "3"},
+		"wrong field":            {ConditionType: ConditionTypeLanguage, Field: "lang", OP: ConditionOpIs, Value: "en"},
+		"interests field":        {ConditionType: ConditionTypeInterests, Field: "abc", OP: ConditionOpContains, Value: []string{"x"}},
+		"empty interests":        NewInterestsCondition("abc", ConditionOpContains),
+		"unknown type":           {ConditionType: "Horoscope", Field: "sign", OP: ConditionOpIs, Value: "leo"},
+		"ipgeoin without place":  NewIPGeoInCondition(ConditionOpIPGeoIn, 10, "", "", ""),
+		"value on valueless op":  {ConditionType: ConditionTypeVIP, Field: "gmonkey", OP: ConditionOpMember, Value: "yes"},
+	}
+
+	for name, cond := range tests {
+		_, err := NewSegmentBuilder(ConditionMatchAny).Where(cond).SegmentOptions()
+		assert.Error(t, err, name)
+	}
+
+	_, err := NewSegmentBuilder("some").Where(NewVIPCondition(ConditionOpMember)).SegmentOptions()
+	assert.Error(t, err)
+}
+
+func TestCampaignSegmentOptionsValidate(t *testing.T) {
+	opts := CampaignCreationSegmentOptions{
+		Match: ConditionMatchAny,
+		Conditions: []InterestsCondition{{
+			ConditionType: ConditionTypeInterests,
+			Field:         "interests-abc",
+			Op:            "interestmaybe",
+			Value:         []string{"x"},
+		}},
+	}
+	assert.Error(t, opts.Validate())
+
+	opts.Conditions = []InterestsCondition{{
+		ConditionType: ConditionTypeInterests,
+		Field:         "interests-abc",
+		Op:            ConditionOpContains,
+		Value:         []string{"x"},
+	}}
+	assert.Nil(t, opts.Validate())
+
+	// untyped payloads are passed through
+	opts.Conditions = []map[string]any{{"field": "whatever"}}
+	assert.Nil(t, opts.Validate())
+}
+
+func TestDecodedSegmentValidate(t *testing.T) {
+	data := `{"match":"all","conditions":[` +
+		`{"condition_type":"Interests","field":"interests-abc","op":"interestcontains","value":["i1","i2"]},` +
+		`{"condition_type":"MemberRating","field":"rating","op":"greater","value":3},` +
+		`{"condition_type":"VIP","field":"gmonkey","op":"member","value":null}]}`
+
+	opts := new(SegmentOptions)
+	fatalIf(t, json.Unmarshal([]byte(data), opts))
+	assert.IsType(t, []any{}, opts.Conditions[0].Value)
+	assert.Nil(t, opts.Validate())
+
+	encoded, err := json.Marshal(opts)
+	fatalIf(t, err)
+	assert.JSONEq(t, data, string(encoded))
+
+	opts.Conditions[0].Value = []any{"i1", 2}
+	assert.Error(t, opts.Validate())
+
+	opts.Conditions[0].Value = []any{}
+	assert.Error(t, opts.Validate())
+}
+
+func TestCampaignRequiresBody(t *testing.T) {
+	api := testAPI()
+
+	_, err := api.CreateCampaign(context.Background(), nil)
+	assert.Error(t, err)
+
+	_, err = api.UpdateCampaign(context.Background(), "c1", nil)
+	assert.Error(t, err)
+}
+
+func TestSegmentRequiresBody(t *testing.T) {
+	list := &ListResponse{ID: "list1", api: testAPI()}
+
+	_, err := list.CreateSegment(context.Background(), nil)
+	assert.Error(t, err)
+
+	_, err = list.UpdateSegment(context.Background(), "1", nil)
+	assert.Error(t, err)
+}
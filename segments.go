@@ -4,11 +4,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
 )
 
 const (
-	segmentsPath      = "/lists/%s/segments"
-	singleSegmentPath = segmentsPath + "/%s"
+	segmentsPath       = "/lists/%s/segments"
+	singleSegmentPath  = segmentsPath + "/%s"
+	segmentMembersPath = singleSegmentPath + "/members"
+	segmentMemberPath  = segmentMembersPath + "/%s"
+
+	SegmentTypeSaved  = "saved"
+	SegmentTypeStatic = "static"
+	SegmentTypeFuzzy  = "fuzzy"
 )
 
 type ListOfSegments struct {
@@ -35,6 +45,23 @@ type Segment struct {
 	ListID      string `json:"list_id"`
 
 	withLinks
+	api *API
+}
+
+func (segment *Segment) CanMakeRequest() error {
+	if segment.ListID == "" {
+		return errors.New("No ListID provided on segment")
+	}
+
+	if segment.ID == "" {
+		return errors.New("No ID provided on segment")
+	}
+
+	if segment.api == nil {
+		return errors.New("No API client on segment")
+	}
+
+	return nil
 }
 
 type SegmentOptions struct {
@@ -72,11 +99,19 @@ type SegmentBatchError struct {
 	Error          string   `json:"error"`
 }
 
-// SegmentConditional represents parameters to filter by
+// SegmentConditional represents parameters to filter by. Use the
+// New*Condition constructors to build one of the documented condition types.
 type SegmentConditional struct {
-	Field string `json:"field"`
-	OP    string `json:"op"`
-	Value any    `json:"value"`
+	ConditionType string `json:"condition_type,omitempty"` // one of the ConditionType* consts
+	Field         string `json:"field"`
+	OP            string `json:"op"`
+	Value         any    `json:"value"`
+	Extra         any    `json:"extra,omitempty"`
+
+	// Only used by IPGeoIn conditions
+	Addr string `json:"addr,omitempty"`
+	Lat  string `json:"lat,omitempty"`
+	Lng  string `json:"lng,omitempty"`
 }
 
 type SegmentQueryParams struct {
@@ -109,7 +144,16 @@ func (list *ListResponse) GetSegments(ctx context.Context, params *SegmentQueryP
 	endpoint := fmt.Sprintf(segmentsPath, list.ID)
 	response := new(ListOfSegments)
 
-	return response, list.api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+	err := list.api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range response.Segments {
+		response.Segments[i].api = list.api
+	}
+
+	return response, nil
 }
 
 func (list *ListResponse) GetSegment(ctx context.Context, id string, params *BasicQueryParams) (*Segment, error) {
@@ -119,6 +163,7 @@ func (list *ListResponse) GetSegment(ctx context.Context, id string, params *Bas
 
 	endpoint := fmt.Sprintf(singleSegmentPath, list.ID, id)
 	response := new(Segment)
+	response.api = list.api
 
 	return response, list.api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
 }
@@ -128,8 +173,18 @@ func (list *ListResponse) CreateSegment(ctx context.Context, body *SegmentReques
 		return nil, err
 	}
 
+	if body == nil {
+		return nil, errors.New("no segment provided")
+	}
+	if body.Options != nil {
+		if err := body.Options.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	endpoint := fmt.Sprintf(segmentsPath, list.ID)
 	response := new(Segment)
+	response.api = list.api
 
 	return response, list.api.Request(ctx, http.MethodPost, endpoint, nil, &body, response)
 }
@@ -139,8 +194,18 @@ func (list *ListResponse) UpdateSegment(ctx context.Context, id string, body *Se
 		return nil, err
 	}
 
+	if body == nil {
+		return nil, errors.New("no segment provided")
+	}
+	if body.Options != nil {
+		if err := body.Options.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	endpoint := fmt.Sprintf(singleSegmentPath, list.ID, id)
 	response := new(Segment)
+	response.api = list.api
 
 	return response, list.api.Request(ctx, http.MethodPatch, endpoint, nil, &body, response)
 }
@@ -168,3 +233,226 @@ func (list *ListResponse) DeleteSegment(ctx context.Context, id string) (bool, e
 	endpoint := fmt.Sprintf(singleSegmentPath, list.ID, id)
 	return list.api.RequestOk(ctx, http.MethodDelete, endpoint)
 }
+
+func (list *ListResponse) GetSegmentMembers(ctx context.Context, id string, params *ExtendedQueryParams) (*ListOfMembers, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf(segmentMembersPath, list.ID, id)
+	response := new(ListOfMembers)
+
+	err := list.api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range response.Members {
+		response.Members[i].api = list.api
+	}
+
+	return response, nil
+}
+
+// IterateSegmentMembers returns an iterator over all members of the segment.
+func (list *ListResponse) IterateSegmentMembers(id string, params *ExtendedQueryParams) *MemberIterator {
+	query := new(ExtendedQueryParams)
+	if params != nil {
+		*query = *params
+	}
+
+	return newMemberIterator(query.Count, func(ctx context.Context, offset, count int) (*ListOfMembers, error) {
+		query.Offset = offset
+		query.Count = count
+		return list.GetSegmentMembers(ctx, id, query)
+	})
+}
+
+func (list *ListResponse) AddSegmentMember(ctx context.Context, id, email string) (*Member, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf(segmentMembersPath, list.ID, id)
+	response := new(Member)
+	response.api = list.api
+
+	body := struct {
+		EmailAddress string `json:"email_address"`
+	}{
+		EmailAddress: email,
+	}
+
+	return response, list.api.Request(ctx, http.MethodPost, endpoint, nil, &body, response)
+}
+
+func (list *ListResponse) RemoveSegmentMember(ctx context.Context, id, email string) (bool, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return false, err
+	}
+
+	endpoint := fmt.Sprintf(segmentMemberPath, list.ID, id, SubscriberHash(email))
+	return list.api.RequestOk(ctx, http.MethodDelete, endpoint)
+}
+
+func (segment *Segment) list() *ListResponse {
+	return segment.api.NewListResponse(segment.ListID)
+}
+
+func (segment *Segment) GetMembers(ctx context.Context, params *ExtendedQueryParams) (*ListOfMembers, error) {
+	if err := segment.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return segment.list().GetSegmentMembers(ctx, segment.ID, params)
+}
+
+// IterateMembers returns an iterator over all members of the segment.
+func (segment *Segment) IterateMembers(params *ExtendedQueryParams) *MemberIterator {
+	if err := segment.CanMakeRequest(); err != nil {
+		return &MemberIterator{err: err}
+	}
+
+	return segment.list().IterateSegmentMembers(segment.ID, params)
+}
+
+func (segment *Segment) AddMember(ctx context.Context, email string) (*Member, error) {
+	if err := segment.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return segment.list().AddSegmentMember(ctx, segment.ID, email)
+}
+
+func (segment *Segment) RemoveMember(ctx context.Context, email string) (bool, error) {
+	if err := segment.CanMakeRequest(); err != nil {
+		return false, err
+	}
+
+	return segment.list().RemoveSegmentMember(ctx, segment.ID, email)
+}
+
+// ------------------------------------------------------------------------------------------------
+// Static Segment Sync
+// ------------------------------------------------------------------------------------------------
+
+// SegmentSyncResult summarizes the changes made by SyncStaticSegment.
+type SegmentSyncResult struct {
+	Added     []string
+	Removed   []string
+	Failed    []string
+	Unchanged int
+
+	Errors []SegmentBatchError
+}
+
+// SyncStaticSegment makes the static segment contain exactly the desired
+// email addresses. Current membership is diffed against desired and only the
+// difference is sent, in chunks of at most 500 additions and removals.
+func (list *ListResponse) SyncStaticSegment(ctx context.Context, id string, desiredEmails []string) (*SegmentSyncResult, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	params := new(ExtendedQueryParams)
+	params.Fields = []string{"members.email_address", "total_items"}
+
+	var current []string
+	members := list.IterateSegmentMembers(id, params)
+	for members.Next(ctx) {
+		current = append(current, members.Member().EmailAddress)
+	}
+	if err := members.Err(); err != nil {
+		return nil, err
+	}
+
+	toAdd, toRemove, unchanged := diffSegmentMembers(current, desiredEmails)
+	result := &SegmentSyncResult{Unchanged: unchanged}
+
+	for _, body := range segmentSyncBatches(toAdd, toRemove) {
+		response, err := list.BatchModifySegment(ctx, id, body)
+		if err != nil {
+			return result, err
+		}
+
+		failed := make(map[string]bool)
+		for _, batchError := range response.Errors {
+			for _, email := range batchError.EmailAddresses {
+				failed[strings.ToLower(email)] = true
+			}
+		}
+		result.Errors = append(result.Errors, response.Errors...)
+
+		for _, email := range body.MembersToAdd {
+			if failed[strings.ToLower(email)] {
+				result.Failed = append(result.Failed, email)
+			} else {
+				result.Added = append(result.Added, email)
+			}
+		}
+
+		for _, email := range body.MembersToRemove {
+			if failed[strings.ToLower(email)] {
+				result.Failed = append(result.Failed, email)
+			} else {
+				result.Removed = append(result.Removed, email)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// diffSegmentMembers compares email addresses ignoring case. The additions
+// keep the order of desired, the removals are sorted.
+func diffSegmentMembers(current, desired []string) (toAdd, toRemove []string, unchanged int) {
+	members := make(map[string]string, len(current))
+	for _, email := range current {
+		members[strings.ToLower(email)] = email
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	for _, email := range desired {
+		key := strings.ToLower(email)
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+
+		if _, ok := members[key]; ok {
+			unchanged++
+		} else {
+			toAdd = append(toAdd, email)
+		}
+	}
+
+	for key, email := range members {
+		if !wanted[key] {
+			toRemove = append(toRemove, email)
+		}
+	}
+	sort.Strings(toRemove)
+
+	return toAdd, toRemove, unchanged
+}
+
+// segmentSyncBatches pairs up chunks of additions and removals, each batch
+// holds at most 500 of either.
+func segmentSyncBatches(toAdd, toRemove []string) []*SegmentBatchRequest {
+	adds := chunkStrings(toAdd, maxSegmentBatchSize)
+	removes := chunkStrings(toRemove, maxSegmentBatchSize)
+
+	var batches []*SegmentBatchRequest
+	for i := 0; i < len(adds) || i < len(removes); i++ {
+		body := &SegmentBatchRequest{MembersToAdd: []string{}, MembersToRemove: []string{}}
+		if i < len(adds) {
+			body.MembersToAdd = adds[i]
+		}
+		if i < len(removes) {
+			body.MembersToRemove = removes[i]
+		}
+		batches = append(batches, body)
+	}
+
+	return batches
+}
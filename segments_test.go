@@ -0,0 +1,140 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterateSegmentMembers(t *testing.T) {
+	var offsets []string
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/lists/list1/segments/seg1/members", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("count"))
+
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		switch offset {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"members":[{"email_address":"a@example.com"},{"email_address":"b@example.com"}],"total_items":3}`)
+		default:
+			_, _ = fmt.Fprint(w, `{"members":[{"email_address":"c@example.com"}],"total_items":3}`)
+		}
+	}
+
+	list := &ListResponse{ID: "list1", api: testAPI()}
+	params := &ExtendedQueryParams{Count: 2, Offset: 7}
+
+	var emails []string
+	it := list.IterateSegmentMembers("seg1", params)
+	for it.Next(context.Background()) {
+		emails = append(emails, it.Member().EmailAddress)
+	}
+	fatalIf(t, it.Err())
+
+	assert.Equal(t, []string{"a@example.com", "b@example.com", "c@example.com"}, emails)
+	assert.Equal(t, []string{"0", "2"}, offsets)
+	assert.Equal(t, &ExtendedQueryParams{Count: 2, Offset: 7}, params)
+}
+
+func TestIterateMembersCopiesParams(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/lists/list1/members", r.URL.Path)
+		assert.Equal(t, "subscribed", r.URL.Query().Get("status"))
+		_, _ = fmt.Fprint(w, `{"members":[{"email_address":"a@example.com"}],"total_items":1}`)
+	}
+
+	list := &ListResponse{ID: "list1", api: testAPI()}
+	params := new(InterestCategoriesQueryParams)
+	params.Status = "subscribed"
+	params.Offset = 5
+
+	it := list.IterateMembers(params)
+	assert.True(t, it.Next(context.Background()))
+	assert.False(t, it.Next(context.Background()))
+	fatalIf(t, it.Err())
+
+	assert.Equal(t, 5, params.Offset)
+	assert.Equal(t, 0, params.Count)
+}
+
+func TestDiffSegmentMembers(t *testing.T) {
+	tests := map[string]struct {
+		current   []string
+		desired   []string
+		toAdd     []string
+		toRemove  []string
+		unchanged int
+	}{
+		"ignores case": {
+			current:   []string{"Jane@Example.com", "joe@example.com"},
+			desired:   []string{"jane@example.com", "JOE@example.com"},
+			unchanged: 2,
+		},
+		"adds and removes": {
+			current:   []string{"b@example.com", "a@example.com", "c@example.com"},
+			desired:   []string{"c@example.com", "e@example.com", "d@example.com"},
+			toAdd:     []string{"e@example.com", "d@example.com"},
+			toRemove:  []string{"a@example.com", "b@example.com"},
+			unchanged: 1,
+		},
+		"duplicates in desired": {
+			desired: []string{"a@example.com", "A@example.com"},
+			toAdd:   []string{"a@example.com"},
+		},
+		"empty target": {
+			current:  []string{"b@example.com", "a@example.com"},
+			toRemove: []string{"a@example.com", "b@example.com"},
+		},
+		"nothing to do": {},
+	}
+
+	for name, test := range tests {
+		toAdd, toRemove, unchanged := diffSegmentMembers(test.current, test.desired)
+		assert.Equal(t, test.toAdd, toAdd, name)
+		assert.Equal(t, test.toRemove, toRemove, name)
+		assert.Equal(t, test.unchanged, unchanged, name)
+	}
+}
+
+func TestSegmentSyncBatches(t *testing.T) {
+	emails := func(prefix string, n int) []string {
+		values := make([]string, n)
+		for i := range values {
+			values[i] = fmt.Sprintf("%s%d@example.com", prefix, i)
+		}
+		return values
+	}
+
+	batches := segmentSyncBatches(emails("add", 1200), emails("remove", 600))
+	assert.Len(t, batches, 3)
+	assert.Len(t, batches[0].MembersToAdd, 500)
+	assert.Len(t, batches[0].MembersToRemove, 500)
+	assert.Len(t, batches[1].MembersToAdd, 500)
+	assert.Len(t, batches[1].MembersToRemove, 100)
+	assert.Len(t, batches[2].MembersToAdd, 200)
+	assert.Equal(t, []string{}, batches[2].MembersToRemove)
+
+	assert.Empty(t, segmentSyncBatches(nil, nil))
+}
+
+func TestSegmentIterateMembersGuard(t *testing.T) {
+	segment := &Segment{ListID: "list1", api: testAPI()}
+
+	it := segment.IterateMembers(nil)
+	assert.False(t, it.Next(context.Background()))
+	assert.Error(t, it.Err())
+}
+
+func TestSegmentRequiresAPI(t *testing.T) {
+	segment := &Segment{ListID: "list1", ID: "1"}
+
+	_, err := segment.GetMembers(context.Background(), nil)
+	assert.Error(t, err)
+
+	_, err = segment.AddMember(context.Background(), "a@example.com")
+	assert.Error(t, err)
+}
@@ -0,0 +1,501 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	json "github.com/json-iterator/go"
+)
+
+const (
+	tagSearchPath = "/lists/%s/tag-search"
+
+	TagStatusActive   = "active"
+	TagStatusInactive = "inactive"
+
+	maxSegmentBatchSize      = 500
+	maxBatchOperations       = 10000
+	defaultBatchPollInterval = 5 * time.Second
+)
+
+type BulkTagOptions struct {
+	// IsSyncing applies the tag without triggering automations. The static
+	// segment endpoint has no such flag, so this goes through batch
+	// operations.
+	IsSyncing bool
+
+	// UseBatch sends the changes through the batch operations endpoint even
+	// when IsSyncing is not set.
+	UseBatch bool
+
+	// ChunkSize is the number of emails sent per request, defaults to 500
+	// for segment requests and 10000 operations per batch.
+	ChunkSize int
+
+	// PollInterval is how often batch operations are polled for completion.
+	PollInterval time.Duration
+}
+
+type BulkTagResult struct {
+	EmailAddress string
+	Success      bool
+	Error        string
+}
+
+type BulkTagResponse struct {
+	Tag            string
+	Results        []BulkTagResult
+	TotalSucceeded int
+	TotalFailed    int
+
+	// BatchIDs lists the batch operations used, if any.
+	BatchIDs []string
+}
+
+func (response *BulkTagResponse) add(email string, err string) {
+	result := BulkTagResult{EmailAddress: email, Success: err == "", Error: err}
+	response.Results = append(response.Results, result)
+
+	if result.Success {
+		response.TotalSucceeded++
+	} else {
+		response.TotalFailed++
+	}
+}
+
+// Failed returns the email addresses that could not be (un)tagged.
+func (response *BulkTagResponse) Failed() []string {
+	var failed []string
+	for _, result := range response.Results {
+		if !result.Success {
+			failed = append(failed, result.EmailAddress)
+		}
+	}
+
+	return failed
+}
+
+// BulkTag adds the tag to every email address. Tags are static segments, so
+// the members are added to the tag's segment in chunks, creating the tag if
+// it does not exist yet.
+func (list *ListResponse) BulkTag(ctx context.Context, tag string, emails []string, opts *BulkTagOptions) (*BulkTagResponse, error) {
+	return list.bulkTag(ctx, tag, emails, TagStatusActive, opts)
+}
+
+// BulkUntag removes the tag from every email address.
+func (list *ListResponse) BulkUntag(ctx context.Context, tag string, emails []string, opts *BulkTagOptions) (*BulkTagResponse, error) {
+	return list.bulkTag(ctx, tag, emails, TagStatusInactive, opts)
+}
+
+func (list *ListResponse) bulkTag(ctx context.Context, tag string, emails []string, status string, opts *BulkTagOptions) (*BulkTagResponse, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	if tag == "" {
+		return nil, errors.New("no tag name provided")
+	}
+
+	if opts == nil {
+		opts = new(BulkTagOptions)
+	}
+
+	if opts.IsSyncing || opts.UseBatch {
+		return list.bulkTagBatch(ctx, tag, emails, status, opts)
+	}
+
+	return list.bulkTagSegment(ctx, tag, emails, status, opts)
+}
+
+func (list *ListResponse) bulkTagSegment(ctx context.Context, tag string, emails []string, status string, opts *BulkTagOptions) (*BulkTagResponse, error) {
+	response := &BulkTagResponse{Tag: tag}
+
+	segment, err := list.findTagSegment(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if segment == nil {
+		if status == TagStatusInactive {
+			// nobody carries a tag that does not exist
+			for _, email := range emails {
+				response.add(email, "")
+			}
+			return response, nil
+		}
+
+		segment, err = list.CreateSegment(ctx, &SegmentRequest{Name: tag, StaticSegment: []string{}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, chunk := range chunkStrings(emails, chunkSize(opts.ChunkSize, maxSegmentBatchSize)) {
+		body := &SegmentBatchRequest{MembersToAdd: []string{}, MembersToRemove: []string{}}
+		if status == TagStatusActive {
+			body.MembersToAdd = chunk
+		} else {
+			body.MembersToRemove = chunk
+		}
+
+		batch, err := list.BatchModifySegment(ctx, segment.ID, body)
+		if err != nil {
+			return response, err
+		}
+
+		failures := make(map[string]string)
+		for _, batchError := range batch.Errors {
+			for _, email := range batchError.EmailAddresses {
+				failures[strings.ToLower(email)] = batchError.Error
+			}
+		}
+
+		for _, email := range chunk {
+			response.add(email, failures[strings.ToLower(email)])
+		}
+	}
+
+	return response, nil
+}
+
+func (list *ListResponse) bulkTagBatch(ctx context.Context, tag string, emails []string, status string, opts *BulkTagOptions) (*BulkTagResponse, error) {
+	response := &BulkTagResponse{Tag: tag}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultBatchPollInterval
+	}
+
+	type submitted struct {
+		id     string
+		emails []string
+	}
+
+	// all batches are submitted before waiting, so Mailchimp works on them
+	// in parallel
+	var batches []submitted
+	for _, chunk := range chunkStrings(emails, chunkSize(opts.ChunkSize, maxBatchOperations)) {
+		request, err := bulkTagBatchRequest(list.ID, tag, status, opts.IsSyncing, chunk)
+		if err != nil {
+			return response, err
+		}
+
+		batch, err := list.api.CreateBatchOperation(ctx, request)
+		if err != nil {
+			return response, err
+		}
+		response.BatchIDs = append(response.BatchIDs, batch.ID)
+		batches = append(batches, submitted{id: batch.ID, emails: chunk})
+	}
+
+	for _, submitted := range batches {
+		batch, err := list.api.WaitForBatchOperation(ctx, submitted.id, interval)
+		if err != nil {
+			return response, err
+		}
+
+		results, err := batch.GetResults(ctx)
+		if err != nil {
+			return response, err
+		}
+
+		response.addBatchResults(submitted.emails, results)
+	}
+
+	return response, nil
+}
+
+// bulkTagBatchRequest builds the batch of member tag updates, one operation
+// per email address with the address as operation ID.
+func bulkTagBatchRequest(listID, tag, status string, isSyncing bool, emails []string) (*BatchOperationCreationRequest, error) {
+	body, err := json.Marshal(struct {
+		Tags      []UpdateMemberTag `json:"tags"`
+		IsSyncing bool              `json:"is_syncing"`
+	}{
+		Tags:      []UpdateMemberTag{{Name: tag, Status: status}},
+		IsSyncing: isSyncing,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	request := &BatchOperationCreationRequest{Operations: make([]BatchOperation, 0, len(emails))}
+	for _, email := range emails {
+		request.Operations = append(request.Operations, BatchOperation{
+			Method:      http.MethodPost,
+			Path:        fmt.Sprintf(memberTagsPath, listID, SubscriberHash(email)),
+			Body:        string(body),
+			OperationID: email,
+		})
+	}
+
+	return request, nil
+}
+
+func (response *BulkTagResponse) addBatchResults(emails []string, results []BatchOperationResult) {
+	byEmail := make(map[string]*BatchOperationResult, len(results))
+	for i := range results {
+		byEmail[results[i].OperationID] = &results[i]
+	}
+
+	for _, email := range emails {
+		result, ok := byEmail[email]
+		switch {
+		case !ok:
+			response.add(email, "no result returned for operation")
+		case result.Succeeded():
+			response.add(email, "")
+		default:
+			response.add(email, result.Error().Error())
+		}
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+// Tag Catalog
+// ------------------------------------------------------------------------------------------------
+
+type TagSearchQueryParams struct {
+	BasicQueryParams
+
+	Name string
+}
+
+func (q *TagSearchQueryParams) Params() map[string]string {
+	m := q.BasicQueryParams.Params()
+	m["name"] = q.Name
+	return m
+}
+
+type TagSearchResponse struct {
+	Tags       []MemberTag `json:"tags"`
+	TotalItems int         `json:"total_items"`
+}
+
+// ListTag is a tag of a list together with the static segment backing it.
+type ListTag struct {
+	Name        string
+	SegmentID   string
+	MemberCount int
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+func newListTag(segment *Segment) ListTag {
+	return ListTag{
+		Name:        segment.Name,
+		SegmentID:   segment.ID,
+		MemberCount: segment.MemberCount,
+		CreatedAt:   segment.CreatedAt,
+		UpdatedAt:   segment.UpdatedAt,
+	}
+}
+
+func (list *ListResponse) SearchTags(ctx context.Context, params *TagSearchQueryParams) (*TagSearchResponse, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf(tagSearchPath, list.ID)
+	response := new(TagSearchResponse)
+
+	return response, list.api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+// GetTags returns every tag on the list with its member count, sorted by name.
+// Mailchimp stores tags as static segments and the API does not tell them
+// apart, so static segments created through the segments endpoint are listed
+// as tags too.
+func (list *ListResponse) GetTags(ctx context.Context) ([]ListTag, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	params := new(SegmentQueryParams)
+	params.Type = SegmentTypeStatic
+	params.Count = maxPageSize
+
+	var tags []ListTag
+	for {
+		segments, err := list.GetSegments(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range segments.Segments {
+			tags = append(tags, newListTag(&segments.Segments[i]))
+		}
+
+		params.Offset += len(segments.Segments)
+		if len(segments.Segments) == 0 || params.Offset >= segments.TotalItems {
+			break
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// FindTagsByPrefix returns the tags whose name starts with prefix, ignoring
+// case, with their member counts.
+func (list *ListResponse) FindTagsByPrefix(ctx context.Context, prefix string) ([]ListTag, error) {
+	search, err := list.SearchTags(ctx, &TagSearchQueryParams{Name: prefix})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make(map[string]bool)
+	for _, tag := range search.Tags {
+		if strings.HasPrefix(strings.ToLower(tag.Name), strings.ToLower(prefix)) {
+			matches[tag.Name] = true
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	tags, err := list.GetTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []ListTag
+	for _, tag := range tags {
+		if matches[tag.Name] {
+			found = append(found, tag)
+		}
+	}
+
+	return found, nil
+}
+
+// GetTag returns the named tag, or an error if it does not exist on the list.
+func (list *ListResponse) GetTag(ctx context.Context, name string) (*ListTag, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	segment, err := list.findTagSegment(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if segment == nil {
+		return nil, errors.Errorf("tag %q not found on list %s", name, list.ID)
+	}
+
+	tag := newListTag(segment)
+	return &tag, nil
+}
+
+// RenameTag renames the tag in place, so all members keep it.
+func (list *ListResponse) RenameTag(ctx context.Context, name, newName string) (*ListTag, error) {
+	if newName == "" {
+		return nil, errors.New("no new tag name provided")
+	}
+
+	tag, err := list.GetTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := list.findTagSegment(ctx, newName)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, errors.Errorf("tag %q already exists on list %s", newName, list.ID)
+	}
+
+	endpoint := fmt.Sprintf(singleSegmentPath, list.ID, tag.SegmentID)
+	response := new(Segment)
+
+	body := struct {
+		Name string `json:"name"`
+	}{
+		Name: newName,
+	}
+
+	err = list.api.Request(ctx, http.MethodPatch, endpoint, nil, &body, response)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := newListTag(response)
+	return &renamed, nil
+}
+
+// DeleteTag removes the tag from the list and from all its members.
+func (list *ListResponse) DeleteTag(ctx context.Context, name string) (bool, error) {
+	tag, err := list.GetTag(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	return list.DeleteSegment(ctx, tag.SegmentID)
+}
+
+// IterateTagMembers returns an iterator over the members carrying the tag.
+func (list *ListResponse) IterateTagMembers(ctx context.Context, name string, params *ExtendedQueryParams) (*MemberIterator, error) {
+	tag, err := list.GetTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.IterateSegmentMembers(tag.SegmentID, params), nil
+}
+
+// findTagSegment returns the static segment backing the tag, nil if the tag
+// does not exist on the list.
+func (list *ListResponse) findTagSegment(ctx context.Context, tag string) (*Segment, error) {
+	params := new(SegmentQueryParams)
+	params.Type = SegmentTypeStatic
+	params.Count = maxPageSize
+
+	for {
+		segments, err := list.GetSegments(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range segments.Segments {
+			if segments.Segments[i].Name == tag {
+				return &segments.Segments[i], nil
+			}
+		}
+
+		params.Offset += len(segments.Segments)
+		if len(segments.Segments) == 0 || params.Offset >= segments.TotalItems {
+			return nil, nil
+		}
+	}
+}
+
+func chunkSize(size, limit int) int {
+	if size <= 0 || size > limit {
+		return limit
+	}
+
+	return size
+}
+
+func chunkStrings(values []string, size int) [][]string {
+	var chunks [][]string
+	for len(values) > size {
+		chunks = append(chunks, values[:size])
+		values = values[size:]
+	}
+
+	if len(values) > 0 {
+		chunks = append(chunks, values)
+	}
+
+	return chunks
+}
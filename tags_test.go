@@ -0,0 +1,77 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkStrings(t *testing.T) {
+	values := []string{"a", "b", "c", "d", "e"}
+
+	assert.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e"}}, chunkStrings(values, 2))
+	assert.Equal(t, [][]string{values}, chunkStrings(values, 5))
+	assert.Equal(t, [][]string{values}, chunkStrings(values, 10))
+	assert.Nil(t, chunkStrings(nil, 2))
+
+	assert.Equal(t, 500, chunkSize(0, 500))
+	assert.Equal(t, 500, chunkSize(1000, 500))
+	assert.Equal(t, 100, chunkSize(100, 500))
+}
+
+func TestSubscriberHash(t *testing.T) {
+	// example from the Mailchimp documentation
+	assert.Equal(t, "62eeb292278cc15f5817cb78f7790b08", SubscriberHash("urist.mcvankab@freddiesjokes.com"))
+	assert.Equal(t, SubscriberHash("urist.mcvankab@freddiesjokes.com"), SubscriberHash("Urist.McVankab@FreddiesJokes.com"))
+}
+
+func TestBulkTagBatchRequest(t *testing.T) {
+	request, err := bulkTagBatchRequest("list1", "vip", TagStatusInactive, true, []string{"Urist.McVankab@FreddiesJokes.com", "b@example.com"})
+	fatalIf(t, err)
+
+	assert.Len(t, request.Operations, 2)
+
+	op := request.Operations[0]
+	assert.Equal(t, http.MethodPost, op.Method)
+	assert.Equal(t, "/lists/list1/members/62eeb292278cc15f5817cb78f7790b08/tags", op.Path)
+	assert.Equal(t, `{"tags":[{"name":"vip","status":"inactive"}],"is_syncing":true}`, op.Body)
+	assert.Equal(t, "Urist.McVankab@FreddiesJokes.com", op.OperationID)
+	assert.Equal(t, "b@example.com", request.Operations[1].OperationID)
+}
+
+func TestBulkTagBatchResults(t *testing.T) {
+	response := &BulkTagResponse{Tag: "vip"}
+	response.addBatchResults([]string{"a@example.com", "b@example.com", "c@example.com"}, []BatchOperationResult{
+		{OperationID: "a@example.com", StatusCode: 204},
+		{OperationID: "b@example.com", StatusCode: 400, Response: `{"type":"https://mailchimp.com/developer/marketing/docs/errors/","title":"Invalid Resource","status":400,"detail":"bad member"}`},
+	})
+
+	assert.Equal(t, 1, response.TotalSucceeded)
+	assert.Equal(t, 2, response.TotalFailed)
+	assert.Equal(t, []string{"b@example.com", "c@example.com"}, response.Failed())
+	assert.Contains(t, response.Results[1].Error, "bad member")
+	assert.Equal(t, "no result returned for operation", response.Results[2].Error)
+}
+
+func TestGetTags(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/lists/list1/segments", r.URL.Path)
+		assert.Equal(t, SegmentTypeStatic, r.URL.Query().Get("type"))
+		_, _ = fmt.Fprint(w, `{"segments":[`+
+			`{"id":"2","name":"vip","member_count":3,"type":"static","list_id":"list1"},`+
+			`{"id":"1","name":"beta","member_count":10,"type":"static","list_id":"list1"}`+
+			`],"total_items":2}`)
+	}
+
+	list := &ListResponse{ID: "list1", api: testAPI()}
+	tags, err := list.GetTags(context.Background())
+	fatalIf(t, err)
+
+	assert.Equal(t, []ListTag{
+		{Name: "beta", SegmentID: "1", MemberCount: 10},
+		{Name: "vip", SegmentID: "2", MemberCount: 3},
+	}, tags)
+}
@@ -2,12 +2,15 @@ package gochimp3
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+
+	"github.com/cockroachdb/errors"
 )
 
 const (
-	templateFoldersPath = "/template-folders"
-	// single folder endpoint not implemented
+	templateFoldersPath      = "/template-folders"
+	singleTemplateFolderPath = templateFoldersPath + "/%s"
 )
 
 type TemplateFolderQueryParams struct {
@@ -29,6 +32,14 @@ type TemplateFolder struct {
 	api *API
 }
 
+func (folder *TemplateFolder) CanMakeRequest() error {
+	if folder.ID == "" {
+		return errors.New("No ID provided on template folder")
+	}
+
+	return nil
+}
+
 type TemplateFolderCreationRequest struct {
 	Name string `json:"name"`
 }
@@ -41,8 +52,8 @@ func (api *API) GetTemplateFolders(ctx context.Context, params *TemplateFolderQu
 		return nil, err
 	}
 
-	for _, l := range response.Folders {
-		l.api = api
+	for i := range response.Folders {
+		response.Folders[i].api = api
 	}
 
 	return response, nil
@@ -53,3 +64,137 @@ func (api *API) CreateTemplateFolder(ctx context.Context, body *TemplateFolderCr
 	response.api = api
 	return response, api.Request(ctx, http.MethodPost, templateFoldersPath, nil, body, response)
 }
+
+func (api *API) GetTemplateFolder(ctx context.Context, id string, params *BasicQueryParams) (*TemplateFolder, error) {
+	endpoint := fmt.Sprintf(singleTemplateFolderPath, id)
+
+	response := new(TemplateFolder)
+	response.api = api
+
+	return response, api.Request(ctx, http.MethodGet, endpoint, params, nil, response)
+}
+
+func (api *API) UpdateTemplateFolder(ctx context.Context, id string, body *TemplateFolderCreationRequest) (*TemplateFolder, error) {
+	endpoint := fmt.Sprintf(singleTemplateFolderPath, id)
+
+	response := new(TemplateFolder)
+	response.api = api
+
+	return response, api.Request(ctx, http.MethodPatch, endpoint, nil, body, response)
+}
+
+func (api *API) DeleteTemplateFolder(ctx context.Context, id string) (bool, error) {
+	endpoint := fmt.Sprintf(singleTemplateFolderPath, id)
+	return api.RequestOk(ctx, http.MethodDelete, endpoint)
+}
+
+// FindTemplateFolder returns the first folder with the given name, or nil if
+// there is none.
+func (api *API) FindTemplateFolder(ctx context.Context, name string) (*TemplateFolder, error) {
+	params := new(TemplateFolderQueryParams)
+	params.Count = maxPageSize
+
+	for {
+		folders, err := api.GetTemplateFolders(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range folders.Folders {
+			if folders.Folders[i].Name == name {
+				return &folders.Folders[i], nil
+			}
+		}
+
+		params.Offset += len(folders.Folders)
+		if len(folders.Folders) == 0 || params.Offset >= folders.TotalItems {
+			return nil, nil
+		}
+	}
+}
+
+// EnsureTemplateFolder returns the folder with the given name, creating it if
+// it does not exist yet.
+func (api *API) EnsureTemplateFolder(ctx context.Context, name string) (*TemplateFolder, error) {
+	folder, err := api.FindTemplateFolder(ctx, name)
+	if err != nil || folder != nil {
+		return folder, err
+	}
+
+	return api.CreateTemplateFolder(ctx, &TemplateFolderCreationRequest{Name: name})
+}
+
+// Rename changes the folder name in place.
+func (folder *TemplateFolder) Rename(ctx context.Context, name string) error {
+	if err := folder.CanMakeRequest(); err != nil {
+		return err
+	}
+
+	response, err := folder.api.UpdateTemplateFolder(ctx, folder.ID, &TemplateFolderCreationRequest{Name: name})
+	if err != nil {
+		return err
+	}
+
+	folder.Name = response.Name
+	return nil
+}
+
+func (folder *TemplateFolder) Delete(ctx context.Context) (bool, error) {
+	if err := folder.CanMakeRequest(); err != nil {
+		return false, err
+	}
+
+	return folder.api.DeleteTemplateFolder(ctx, folder.ID)
+}
+
+// templateFolderUpdate patches the folder of a template. The name and html are
+// required by the endpoint, so they are sent back unchanged.
+type templateFolderUpdate struct {
+	Name     string `json:"name"`
+	Html     string `json:"html"`
+	FolderId string `json:"folder_id"`
+}
+
+// MoveTemplates moves the templates into the folder, use an empty folder ID to
+// move them out of any folder. Failures are reported per template; the
+// returned error is only set when the context is done.
+func (api *API) MoveTemplates(ctx context.Context, folderID string, templateIDs ...string) ([]FolderMoveResult, error) {
+	results := make([]FolderMoveResult, 0, len(templateIDs))
+	for _, id := range templateIDs {
+		if err := ctx.Err(); err != nil {
+			return results, errors.WithStack(err)
+		}
+
+		results = append(results, FolderMoveResult{ID: id, Error: api.moveTemplate(ctx, folderID, id)})
+	}
+
+	return results, nil
+}
+
+func (api *API) moveTemplate(ctx context.Context, folderID, id string) error {
+	template, err := api.GetTemplate(ctx, id, &BasicQueryParams{Fields: []string{"name"}})
+	if err != nil {
+		return err
+	}
+
+	content, err := api.GetTemplateDefaultContent(ctx, id, &BasicQueryParams{Fields: []string{"html"}})
+	if err != nil {
+		return err
+	}
+	if content.Html == "" {
+		// sending empty html would wipe the template
+		return errors.Errorf("no html returned for template %s", id)
+	}
+
+	body := &templateFolderUpdate{Name: template.Name, Html: content.Html, FolderId: folderID}
+	endpoint := fmt.Sprintf(singleTemplatePath, id)
+	return api.Request(ctx, http.MethodPatch, endpoint, nil, body, nil)
+}
+
+func (folder *TemplateFolder) MoveTemplates(ctx context.Context, templateIDs ...string) ([]FolderMoveResult, error) {
+	if err := folder.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	return folder.api.MoveTemplates(ctx, folder.ID, templateIDs...)
+}
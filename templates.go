@@ -69,6 +69,7 @@ type TemplateDefaultContentResponse struct {
 	withLinks
 
 	Sections map[string]string `json:"sections"`
+	Html     string            `json:"html,omitempty"`
 
 	api *API
 }